@@ -6,350 +6,226 @@ import (
 	"github.com/ib-77/rop/pkg/rop/mass"
 	"github.com/ib-77/rop/pkg/rop/solo"
 	"sync"
+	"time"
 )
 
 func Validate[T any](ctx context.Context, inputChs chan chan T,
 	validateF func(ctx context.Context, in T) bool,
-	cancelF func(ctx context.Context, in T) error, errMsg string) chan chan rop.Result[T] {
+	cancelF func(ctx context.Context, in T) error, errMsg string,
+	opts ...mass.Option) chan chan rop.Result[T] {
 
-	outChs, outs := makeOutputChs[T](len(inputChs))
-
-	var wg sync.WaitGroup
-	wg.Add(1)
-
-	go func() {
-		defer wg.Done()
-
-		chIndex := 0
-		for inputCh := range inputChs {
-
-			outCh := outs[chIndex]
-
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
-
-			wg.Add(1)
-			go func(inCh <-chan T, ouCh chan rop.Result[T]) {
-				defer wg.Done()
-
-				for out := range mass.Validate(ctx, inCh, validateF, cancelF, errMsg) {
-					select {
-					case ouCh <- out:
-						//case <-ctx.Done():    << don't skip cancelled
-					}
-				}
-			}(inputCh, outCh)
-
-			chIndex++
-		}
-	}()
-
-	go func() {
-		wg.Wait()
-		closeOutputChs(outChs, outs)
-	}()
-
-	return outChs
+	return lanes(ctx, inputChs,
+		func(in <-chan T) <-chan rop.Result[T] {
+			return mass.Validate(ctx, in, validateF, cancelF, errMsg, opts...)
+		}, opts...)
 }
 
 func AndValidate[T any](ctx context.Context, inputChs chan chan rop.Result[T],
 	validateF func(ctx context.Context, in T) bool,
-	cancelF func(ctx context.Context, in T) error, errMsg string) chan chan rop.Result[T] {
-
-	outChs, outs := makeOutputChs[T](len(inputChs))
+	cancelF func(ctx context.Context, in T) error, errMsg string,
+	opts ...mass.Option) chan chan rop.Result[T] {
 
-	var wg sync.WaitGroup
-	wg.Add(1)
-
-	go func() {
-		defer wg.Done()
-
-		chIndex := 0
-		for inputCh := range inputChs {
-
-			outCh := outs[chIndex]
-
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
-
-			wg.Add(1)
-			go func(inCh <-chan rop.Result[T], ouCh chan rop.Result[T]) {
-				defer wg.Done()
-
-				for out := range mass.AndValidate(ctx, inCh, validateF, cancelF, errMsg) {
-					select {
-					case ouCh <- out:
-						//case <-ctx.Done():    << don't skip cancelled
-					}
-				}
-			}(inputCh, outCh)
-
-			chIndex++
-		}
-	}()
-
-	go func() {
-		wg.Wait()
-		closeOutputChs(outChs, outs)
-	}()
-
-	return outChs
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[T]) <-chan rop.Result[T] {
+			return mass.AndValidate(ctx, in, validateF, cancelF, errMsg, opts...)
+		}, opts...)
 }
 
 func Map[In, Out any](ctx context.Context, inputChs chan chan rop.Result[In],
 	mapF func(ctx context.Context, r In) Out,
-	cancelF func(ctx context.Context, r In) error) chan chan rop.Result[Out] {
-
-	outChs, outs := makeOutputChs[Out](len(inputChs))
-
-	var wg sync.WaitGroup
-	wg.Add(1)
-
-	go func() {
-		defer wg.Done()
-
-		chIndex := 0
-		for inputCh := range inputChs {
-
-			outCh := outs[chIndex]
-
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
-
-			wg.Add(1)
-			go func(inCh <-chan rop.Result[In], ouCh chan rop.Result[Out]) {
-				defer wg.Done()
-
-				for out := range mass.Map(ctx, inCh, mapF, cancelF) {
-					select {
-					case ouCh <- out:
-						//case <-ctx.Done():    << don't skip cancelled
-					}
-				}
-			}(inputCh, outCh)
-
-			chIndex++
-		}
-	}()
-
-	go func() {
-		wg.Wait()
-		closeOutputChs(outChs, outs)
-	}()
-
-	return outChs
+	cancelF func(ctx context.Context, r In) error,
+	opts ...mass.Option) chan chan rop.Result[Out] {
 
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[In]) <-chan rop.Result[Out] {
+			return mass.Map(ctx, in, mapF, cancelF, opts...)
+		}, opts...)
 }
 
 func Tee[T any](ctx context.Context, inputChs chan chan rop.Result[T],
 	deadEndF func(ctx context.Context, r rop.Result[T]),
-	cancelF func(ctx context.Context, r T) error) chan chan rop.Result[T] {
-
-	outChs, outs := makeOutputChs[T](len(inputChs))
-
-	var wg sync.WaitGroup
-	wg.Add(1)
-
-	go func() {
-		defer wg.Done()
-
-		chIndex := 0
-		for inputCh := range inputChs {
-
-			outCh := outs[chIndex]
-
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
-
-			wg.Add(1)
-			go func(inCh <-chan rop.Result[T], ouCh chan rop.Result[T]) {
-				defer wg.Done()
-
-				for out := range mass.Tee(ctx, inCh, deadEndF, cancelF) {
-					select {
-					case ouCh <- out:
-						//case <-ctx.Done():    << don't skip cancelled
-					}
-				}
-			}(inputCh, outCh)
-
-			chIndex++
-		}
-	}()
-
-	go func() {
-		wg.Wait()
-		closeOutputChs(outChs, outs)
-	}()
-
-	return outChs
+	cancelF func(ctx context.Context, r T) error,
+	opts ...mass.Option) chan chan rop.Result[T] {
 
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[T]) <-chan rop.Result[T] {
+			return mass.Tee(ctx, in, deadEndF, cancelF, opts...)
+		}, opts...)
 }
 
 func Switch[In, Out any](ctx context.Context, inputChs chan chan rop.Result[In],
 	switchF func(ctx context.Context, r In) rop.Result[Out],
-	cancelF func(ctx context.Context, r In) error) chan chan rop.Result[Out] {
-
-	outChs, outs := makeOutputChs[Out](len(inputChs))
-
-	var wg sync.WaitGroup
-	wg.Add(1)
-
-	go func() {
-		defer wg.Done()
-
-		chIndex := 0
-		for inputCh := range inputChs {
-
-			outCh := outs[chIndex]
-
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
-
-			wg.Add(1)
-			go func(inCh <-chan rop.Result[In], ouCh chan rop.Result[Out]) {
-				defer wg.Done()
-
-				for out := range mass.Switch(ctx, inCh, switchF, cancelF) {
-					select {
-					case ouCh <- out:
-						//case <-ctx.Done():    << don't skip cancelled
-					}
-				}
-			}(inputCh, outCh)
-
-			chIndex++
-		}
-	}()
-
-	go func() {
-		wg.Wait()
-		closeOutputChs(outChs, outs)
-	}()
-
-	return outChs
+	cancelF func(ctx context.Context, r In) error,
+	opts ...mass.Option) chan chan rop.Result[Out] {
 
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[In]) <-chan rop.Result[Out] {
+			return mass.Switch(ctx, in, switchF, cancelF, opts...)
+		}, opts...)
 }
 
 func DoubleMap[In any, Out any](ctx context.Context, inputChs chan chan rop.Result[In],
 	successF func(ctx context.Context, r In) Out,
 	failF func(ctx context.Context, err error) Out,
 	cancelF func(ctx context.Context, err error) Out,
-	massCancelF func(ctx context.Context, r In) error) chan chan rop.Result[Out] {
+	massCancelF func(ctx context.Context, r In) error,
+	opts ...mass.Option) chan chan rop.Result[Out] {
 
-	outChs, outs := makeOutputChs[Out](len(inputChs))
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[In]) <-chan rop.Result[Out] {
+			return mass.DoubleMap(ctx, in, successF, failF, cancelF, massCancelF, opts...)
+		}, opts...)
+}
 
-	var wg sync.WaitGroup
-	wg.Add(1)
+func Try[In, Out any](ctx context.Context, inputChs chan chan rop.Result[In],
+	withErrF func(ctx context.Context, r In) (Out, error),
+	cancelF func(ctx context.Context, r In) error,
+	opts ...mass.Option) chan chan rop.Result[Out] {
 
-	go func() {
-		defer wg.Done()
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[In]) <-chan rop.Result[Out] {
+			return mass.Try(ctx, in, withErrF, cancelF, opts...)
+		}, opts...)
+}
 
-		chIndex := 0
-		for inputCh := range inputChs {
+func Finally[In, Out any](ctx context.Context, inputChs chan chan rop.Result[In],
+	successF func(ctx context.Context, r In) Out,
+	failF func(ctx context.Context, err error) Out,
+	cancelF func(ctx context.Context, r rop.Result[In]) Out,
+	opts ...mass.Option) chan chan Out {
 
-			outCh := outs[chIndex]
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[In]) <-chan Out {
+			return mass.Finally(ctx, in, successF, failF, cancelF, opts...)
+		}, opts...)
+}
 
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
+func RateLimit[T any](ctx context.Context, inputChs chan chan rop.Result[T], limiter *rop.Limiter,
+	cancelF func(ctx context.Context, r T) error,
+	opts ...mass.Option) chan chan rop.Result[T] {
 
-			wg.Add(1)
-			go func(inCh <-chan rop.Result[In], ouCh chan rop.Result[Out]) {
-				defer wg.Done()
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[T]) <-chan rop.Result[T] {
+			return mass.RateLimit(ctx, in, limiter, cancelF, opts...)
+		}, opts...)
+}
 
-				for out := range mass.DoubleMap(ctx, inCh, successF, failF, cancelF, massCancelF) {
-					select {
-					case ouCh <- out:
-						//case <-ctx.Done():    << don't skip cancelled
-					}
-				}
-			}(inputCh, outCh)
+func Timeout[In, Out any](ctx context.Context, inputChs chan chan rop.Result[In],
+	timeout time.Duration, policy solo.TimeoutPolicy,
+	switchF func(ctx context.Context, r In) rop.Result[Out],
+	cancelF func(ctx context.Context, r In) error,
+	opts ...mass.Option) chan chan rop.Result[Out] {
 
-			chIndex++
-		}
-	}()
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[In]) <-chan rop.Result[Out] {
+			return mass.Timeout(ctx, in, timeout, policy, switchF, cancelF, opts...)
+		}, opts...)
+}
 
-	go func() {
-		wg.Wait()
-		closeOutputChs(outChs, outs)
-	}()
+func Recover[T any](ctx context.Context, inputChs chan chan rop.Result[T],
+	recoverF func(ctx context.Context, err error) T, matchF func(err error) bool,
+	cancelF func(ctx context.Context, r T) error,
+	opts ...mass.Option) chan chan rop.Result[T] {
 
-	return outChs
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[T]) <-chan rop.Result[T] {
+			return mass.Recover(ctx, in, recoverF, matchF, cancelF, opts...)
+		}, opts...)
+}
 
+func RecoverWith[T any](ctx context.Context, inputChs chan chan rop.Result[T],
+	altF func(ctx context.Context, err error) rop.Result[T], matchF func(err error) bool,
+	cancelF func(ctx context.Context, r T) error,
+	opts ...mass.Option) chan chan rop.Result[T] {
+
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[T]) <-chan rop.Result[T] {
+			return mass.RecoverWith(ctx, in, altF, matchF, cancelF, opts...)
+		}, opts...)
 }
 
-func Try[In, Out any](ctx context.Context, inputChs chan chan rop.Result[In],
-	withErrF func(ctx context.Context, r In) (Out, error),
-	cancelF func(ctx context.Context, r In) error) chan chan rop.Result[Out] {
+func OrElse[T any](ctx context.Context, inputChs chan chan rop.Result[T],
+	fallback T, matchF func(err error) bool,
+	cancelF func(ctx context.Context, r T) error,
+	opts ...mass.Option) chan chan rop.Result[T] {
 
-	outChs, outs := makeOutputChs[Out](len(inputChs))
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[T]) <-chan rop.Result[T] {
+			return mass.OrElse(ctx, in, fallback, matchF, cancelF, opts...)
+		}, opts...)
+}
 
-	var wg sync.WaitGroup
-	wg.Add(1)
+func FailToCancel[T any](ctx context.Context, inputChs chan chan rop.Result[T],
+	matchF func(err error) bool,
+	cancelF func(ctx context.Context, r T) error,
+	opts ...mass.Option) chan chan rop.Result[T] {
 
-	go func() {
-		defer wg.Done()
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[T]) <-chan rop.Result[T] {
+			return mass.FailToCancel(ctx, in, matchF, cancelF, opts...)
+		}, opts...)
+}
 
-		chIndex := 0
-		for inputCh := range inputChs {
+func CancelToFail[T any](ctx context.Context, inputChs chan chan rop.Result[T],
+	matchF func(err error) bool,
+	cancelF func(ctx context.Context, r T) error,
+	opts ...mass.Option) chan chan rop.Result[T] {
 
-			outCh := outs[chIndex]
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[T]) <-chan rop.Result[T] {
+			return mass.CancelToFail(ctx, in, matchF, cancelF, opts...)
+		}, opts...)
+}
 
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
+func Using[In, R, Out any](ctx context.Context, inputChs chan chan rop.Result[In],
+	acquireF func(ctx context.Context, r In) (R, error),
+	releaseF func(ctx context.Context, r R) error,
+	bodyF func(ctx context.Context, in In, r R) rop.Result[Out],
+	cancelF func(ctx context.Context, r In) error,
+	opts ...mass.Option) chan chan rop.Result[Out] {
+
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[In]) <-chan rop.Result[Out] {
+			return mass.Using(ctx, in, acquireF, releaseF, bodyF, cancelF, opts...)
+		}, opts...)
+}
 
-			wg.Add(1)
-			go func(inCh <-chan rop.Result[In], ouCh chan rop.Result[Out]) {
-				defer wg.Done()
+func Filter[T any](ctx context.Context, inputChs chan chan rop.Result[T],
+	keepF func(ctx context.Context, r T) bool,
+	cancelF func(ctx context.Context, r T) error,
+	opts ...mass.Option) chan chan rop.Result[T] {
 
-				for out := range mass.Try(ctx, inCh, withErrF, cancelF) {
-					select {
-					case ouCh <- out:
-						//case <-ctx.Done():    << don't skip cancelled
-					}
-				}
-			}(inputCh, outCh)
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[T]) <-chan rop.Result[T] {
+			return mass.Filter(ctx, in, keepF, cancelF, opts...)
+		}, opts...)
+}
 
-			chIndex++
-		}
-	}()
+// Lift runs the custom stage op on every lane, see mass.Lift.
+func Lift[In, Out any](ctx context.Context, inputChs chan chan rop.Result[In],
+	op rop.Operator[In, Out], opts ...mass.Option) chan chan rop.Result[Out] {
 
-	go func() {
-		wg.Wait()
-		closeOutputChs(outChs, outs)
-	}()
+	return lanes(ctx, inputChs,
+		func(in <-chan rop.Result[In]) <-chan rop.Result[Out] {
+			return mass.Lift(ctx, in, op, opts...)
+		}, opts...)
+}
 
-	return outChs
+// Lanes runs the mass stage built by stageF on every lane of inputChs. opts
+// set how a lane gives up on its consumer once ctx is done, see mass.Send.
+func Lanes[In, Out any](ctx context.Context, inputChs chan chan In,
+	stageF func(ctx context.Context, in <-chan In) <-chan Out, opts ...mass.Option) chan chan Out {
 
+	return lanes(ctx, inputChs,
+		func(in <-chan In) <-chan Out {
+			return stageF(ctx, in)
+		}, opts...)
 }
 
-func Finally[In, Out any](ctx context.Context, inputChs chan chan rop.Result[In],
-	successF func(ctx context.Context, r In) Out,
-	failF func(ctx context.Context, err error) Out,
-	cancelF func(ctx context.Context, r rop.Result[In]) Out) chan chan Out {
+func lanes[In, Out any](ctx context.Context, inputChs chan chan In,
+	stageF func(in <-chan In) <-chan Out, opts ...mass.Option) chan chan Out {
 
-	outChs, outs := makeFinallyOutputChs[Out](len(inputChs))
+	outChs, outs := makeOutputChs[Out](len(inputChs))
 
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -357,62 +233,49 @@ func Finally[In, Out any](ctx context.Context, inputChs chan chan rop.Result[In]
 	go func() {
 		defer wg.Done()
 
-		chIndex := 0
-		for inputCh := range inputChs {
-
-			outCh := outs[chIndex]
+		for chIndex := 0; chIndex < len(outs); chIndex++ {
 
+			var inputCh chan In
+			var ok bool
 			select {
 			case <-ctx.Done():
 				return
-			default:
+			case inputCh, ok = <-inputChs:
+				if !ok {
+					return
+				}
 			}
 
 			wg.Add(1)
-			go func(inCh <-chan rop.Result[In], ouCh chan Out) {
+			go func(inCh <-chan In, ouCh chan Out) {
 				defer wg.Done()
-
-				for out := range mass.Finally(ctx, inCh, successF, failF, cancelF) {
-					select {
-					case ouCh <- out:
-						//case <-ctx.Done():    << don't skip cancelled
-					}
-				}
-			}(inputCh, outCh)
-
-			chIndex++
+				forward(ctx, stageF(inCh), ouCh, opts)
+			}(inputCh, outs[chIndex])
 		}
 	}()
 
 	go func() {
 		wg.Wait()
-		closeFinallyOutputChs(outChs, outs)
+		closeOutputChs(outChs, outs)
 	}()
 
 	return outChs
-
 }
 
-func makeOutputChs[Out any](outputChCount int) (chan chan rop.Result[Out], []chan rop.Result[Out]) {
-	outputChs := make(chan chan rop.Result[Out], outputChCount)
-	outs := make([]chan rop.Result[Out], outputChCount)
-	for i := 0; i < outputChCount; i++ {
-		c := make(chan rop.Result[Out])
-		outputChs <- c
-		outs[i] = c
-	}
-	return outputChs, outs
-}
+// forward passes the results of a lane stage on to the lane output. When it
+// gives up on the consumer it keeps draining from, so the stage can finish.
+func forward[T any](ctx context.Context, from <-chan T, to chan<- T, opts []mass.Option) {
 
-func closeOutputChs[Out any](outputChs chan chan rop.Result[Out], outs []chan rop.Result[Out]) {
-	for i := 0; i < len(outs); i++ {
-		close(outs[i])
+	for out := range from {
+		if !mass.Send(ctx, to, out, opts...) {
+			for range from {
+			}
+			return
+		}
 	}
-	close(outputChs)
-	outs = nil
 }
 
-func makeFinallyOutputChs[Out any](outputChCount int) (chan chan Out, []chan Out) {
+func makeOutputChs[Out any](outputChCount int) (chan chan Out, []chan Out) {
 	outputChs := make(chan chan Out, outputChCount)
 	outs := make([]chan Out, outputChCount)
 	for i := 0; i < outputChCount; i++ {
@@ -423,7 +286,7 @@ func makeFinallyOutputChs[Out any](outputChCount int) (chan chan Out, []chan Out
 	return outputChs, outs
 }
 
-func closeFinallyOutputChs[Out any](outputChs chan chan Out, outs []chan Out) {
+func closeOutputChs[Out any](outputChs chan chan Out, outs []chan Out) {
 	for i := 0; i < len(outs); i++ {
 		close(outs[i])
 	}
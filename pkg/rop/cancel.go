@@ -0,0 +1,115 @@
+package rop
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrShutdown is the cancel cause to use when the process is shutting down,
+// e.g. cancel(rop.ErrShutdown) with a context from context.WithCancelCause.
+var ErrShutdown = errors.New("shutdown")
+
+// CancelReason tells why a Result ended up on the cancel track.
+type CancelReason int
+
+const (
+	// CancelUnspecified is the reason of Cancel results not caused by a done
+	// context, e.g. built by rop.Cancel in a stage function.
+	CancelUnspecified CancelReason = iota
+	// CancelAborted means the context was cancelled without a more specific cause.
+	CancelAborted
+	// CancelDeadline means a deadline or timeout expired.
+	CancelDeadline
+	// CancelShutdown means the context was cancelled with ErrShutdown.
+	CancelShutdown
+	// CancelBreaker means a circuit breaker or an error budget stopped the run.
+	CancelBreaker
+)
+
+func (r CancelReason) String() string {
+	switch r {
+	case CancelAborted:
+		return "aborted"
+	case CancelDeadline:
+		return "deadline"
+	case CancelShutdown:
+		return "shutdown"
+	case CancelBreaker:
+		return "breaker"
+	default:
+		return "unspecified"
+	}
+}
+
+// CancelReasoner is implemented by cancel causes that know their reason.
+type CancelReasoner interface {
+	CancelReason() CancelReason
+}
+
+// ReasonOf classifies a cancel cause as returned by context.Cause.
+func ReasonOf(cause error) CancelReason {
+
+	if cause == nil {
+		return CancelUnspecified
+	}
+
+	var reasoner CancelReasoner
+	switch {
+	case errors.As(cause, &reasoner):
+		return reasoner.CancelReason()
+	case errors.Is(cause, context.DeadlineExceeded):
+		return CancelDeadline
+	case errors.Is(cause, ErrShutdown):
+		return CancelShutdown
+	default:
+		return CancelAborted
+	}
+}
+
+// CancelBecause is Cancel for a cancellation caused by cause.
+func CancelBecause[T any](err error, cause error) Result[T] {
+	r := Cancel[T](err)
+	r.cause = cause
+	r.reason = ReasonOf(cause)
+	return r
+}
+
+// CancelFromCtx is Cancel with the cause of ctx, if it is done already.
+func CancelFromCtx[T any](ctx context.Context, err error) Result[T] {
+	return CancelBecause[T](err, context.Cause(ctx))
+}
+
+func (r Result[T]) CancelReason() CancelReason {
+	return r.reason
+}
+
+// CancelCause is the context.Cause the result was cancelled with, if any.
+func (r Result[T]) CancelCause() error {
+	return r.cause
+}
+
+func (r Result[T]) IsDeadline() bool {
+	return r.isCancel && r.reason == CancelDeadline
+}
+
+func (r Result[T]) IsShutdown() bool {
+	return r.isCancel && r.reason == CancelShutdown
+}
+
+// GetCancelReasonFromCtx returns the cancel reason of the result a stage
+// function was called for, or the one of ctx itself when it is done. It lets
+// Finally handlers, which only get the error, react to the reason.
+func GetCancelReasonFromCtx(ctx context.Context) CancelReason {
+	if o, ok := ctx.Value(ResultOfKey).(origin); ok && o.reason != CancelUnspecified {
+		return o.reason
+	}
+	return ReasonOf(context.Cause(ctx))
+}
+
+func IsDeadline(ctx context.Context) bool {
+	return GetCancelReasonFromCtx(ctx) == CancelDeadline
+}
+
+func IsShutdown(ctx context.Context) bool {
+	return GetCancelReasonFromCtx(ctx) == CancelShutdown
+}
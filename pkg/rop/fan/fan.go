@@ -72,8 +72,13 @@ func OutRand[T any](ctx context.Context, inputCh chan rop.Result[T], chCount int
 				select {
 				case <-ctx.Done():
 					return
-				default:
-					ch <- <-inputCh
+				case value, ok := <-inputCh:
+					if !ok {
+						return
+					}
+					if !send(ctx, ch, value) {
+						return
+					}
 				}
 			}
 		}(outs[chIndex])
@@ -89,45 +94,24 @@ func OutRand[T any](ctx context.Context, inputCh chan rop.Result[T], chCount int
 
 func OutNext[T any](ctx context.Context, inputCh chan rop.Result[T], chCount int) []chan rop.Result[T] {
 	outs := makeOutputChs[T](chCount)
-	nextChIndex := make(chan int, 1)
 	r := makeRing(chCount)
-	nextChIndex <- r.Value.(int)
-
-	var wg sync.WaitGroup
-	wg.Add(chCount)
-
-	for chIndex := 0; chIndex < chCount; chIndex++ {
-		outCh := outs[chIndex]
-		go func(ch chan<- rop.Result[T], index int) {
-			defer wg.Done()
 
-			for {
-				select {
-				case <-ctx.Done():
+	go func() {
+		defer closeOutputChs[T](outs)
+
+		for ; ; r = r.Next() {
+			select {
+			case <-ctx.Done():
+				return
+			case value, ok := <-inputCh:
+				if !ok {
+					return
+				}
+				if !send(ctx, outs[r.Value.(int)], value) {
 					return
-				default:
-					select {
-					case next, ok := <-nextChIndex:
-						if !ok {
-							break
-						}
-						outs[next] <- <-inputCh
-						r = r.Next()
-						nextChIndex <- r.Value.(int)
-						break
-					default:
-						break
-					}
 				}
 			}
-		}(outCh, chIndex)
-	}
-
-	go func() {
-		wg.Wait()
-		closeOutputChs[T](outs)
-		close(nextChIndex)
-		nextChIndex = nil
+		}
 	}()
 
 	return outs
@@ -151,6 +135,15 @@ func SliceToChs[T any](inputChs []chan T) chan chan T {
 	return outputChs
 }
 
+func send[T any](ctx context.Context, ch chan<- T, value T) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case ch <- value:
+		return true
+	}
+}
+
 func makeRing(count int) *ring.Ring {
 	r := ring.New(count)
 	for i := 0; i < count; i++ {
@@ -0,0 +1,88 @@
+package group
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+)
+
+// NoneAccepted is the label of the Decision made when no branch accepted the input.
+const NoneAccepted = "none accepted"
+
+// DecisionKey is the metadata key the labelled Or operators record their Decision under.
+var DecisionKey = rop.NewKey[Decision]("group.decision")
+
+// Branch is a labelled alternative of OrTeeBranches and OrSwitchBranches.
+type Branch[In any] struct {
+	Label string
+	F     func(ctx context.Context, in rop.Result[In]) (accepted bool, res rop.Result[In])
+}
+
+// Decision tells which branch handled the input.
+type Decision struct {
+	Label    string
+	Accepted bool
+}
+
+// NewBranch labels the alternative f.
+func NewBranch[In any](label string,
+	f func(ctx context.Context, in rop.Result[In]) (accepted bool, res rop.Result[In])) Branch[In] {
+
+	return Branch[In]{Label: label, F: f}
+}
+
+// DecisionOf returns the Decision recorded on r by a labelled Or operator.
+func DecisionOf[T any](r rop.Result[T]) (Decision, bool) {
+	return rop.GetMeta(r, DecisionKey)
+}
+
+// OrTeeBranches works like OrTeeWithCtx and records on the result which
+// branch accepted the input, or NoneAccepted when the input was passed on.
+func OrTeeBranches[In any](ctx context.Context, input rop.Result[In],
+	branches ...Branch[In]) rop.Result[In] {
+
+	if !input.IsSuccess() {
+		return input
+	}
+
+	res, d := orBranches(ctx, input, branches)
+	return rop.Step(ctx, "ortee", input, rop.SetMeta(res, DecisionKey, d))
+}
+
+// OrSwitchBranches works like OrSwitchWithCtx and records the Decision both on
+// the result given to switchF and on the one it returns.
+func OrSwitchBranches[In, Out any](ctx context.Context, input rop.Result[In],
+	switchF func(ctx context.Context, r rop.Result[In]) rop.Result[Out],
+	branches ...Branch[In]) rop.Result[Out] {
+
+	if !input.IsSuccess() {
+		return switchF(ctx, input)
+	}
+
+	res, d := orBranches(ctx, input, branches)
+	res = rop.Step(ctx, "orswitch", input, rop.SetMeta(res, DecisionKey, d))
+
+	if !res.IsSuccess() {
+		return passOn[In, Out](res)
+	}
+
+	return rop.SetMeta(switchF(ctx, res), DecisionKey, d)
+}
+
+func orBranches[In any](ctx context.Context, input rop.Result[In],
+	branches []Branch[In]) (rop.Result[In], Decision) {
+
+	for _, b := range branches {
+
+		accepted, r := alternative(ctx, input, b.F)
+
+		if accepted {
+			d := Decision{Label: b.Label, Accepted: true}
+			if r.IsSuccess() {
+				return rop.Carry(input, r), d
+			}
+			return rop.Carry(input, rop.Carry(r, rop.Fail[In](r.Err()))), d
+		}
+	}
+
+	return input, Decision{Label: NoneAccepted}
+}
@@ -15,14 +15,14 @@ func AndTeeWithCtx[In any](ctx context.Context, input rop.Result[In],
 	res := input
 	for _, f := range fs {
 
-		res = f(ctx, res)
+		res = member(ctx, res, f)
 
 		if !res.IsSuccess() {
-			return rop.Fail[In](res.Err())
+			return rop.Step(ctx, "andtee", input, rop.Carry(res, rop.Fail[In](res.Err())))
 		}
 	}
 
-	return res
+	return rop.Step(ctx, "andtee", input, res)
 }
 
 func AndSwitchWithCtx[In, Out any](ctx context.Context, input rop.Result[In],
@@ -36,14 +36,14 @@ func AndSwitchWithCtx[In, Out any](ctx context.Context, input rop.Result[In],
 	res := input
 	for _, f := range fs {
 
-		res = f(ctx, res)
+		res = member(ctx, res, f)
 
 		if !res.IsSuccess() {
-			return rop.Fail[Out](res.Err())
+			return rop.Step(ctx, "andswitch", input, rop.Carry(res, rop.Fail[Out](res.Err())))
 		}
 	}
 
-	return switchF(ctx, res)
+	return switchF(ctx, rop.Step(ctx, "andswitch", input, res))
 }
 
 func OrTeeWithCtx[In any](ctx context.Context, input rop.Result[In],
@@ -59,18 +59,18 @@ func OrTeeWithCtx[In any](ctx context.Context, input rop.Result[In],
 
 	for _, f := range fs {
 
-		accepted, r := f(ctx, input)
+		accepted, r := alternative(ctx, input, f)
 
 		if accepted {
 			if r.IsSuccess() {
-				return r
+				return rop.Step(ctx, "ortee", input, r)
 			}
-			return rop.Fail[In](r.Err())
+			return rop.Step(ctx, "ortee", input, rop.Carry(r, rop.Fail[In](r.Err())))
 		}
 
 	}
 
-	return input
+	return rop.Step(ctx, "ortee", input, input)
 }
 
 func OrSwitchWithCtx[In, Out any](ctx context.Context, input rop.Result[In],
@@ -87,17 +87,40 @@ func OrSwitchWithCtx[In, Out any](ctx context.Context, input rop.Result[In],
 
 	for _, f := range fs {
 
-		accepted, r := f(ctx, input)
+		accepted, r := alternative(ctx, input, f)
 
 		if accepted {
 
 			if r.IsSuccess() {
-				return switchF(ctx, r)
+				return switchF(ctx, rop.Step(ctx, "orswitch", input, r))
 			}
 
-			return rop.Fail[Out](r.Err())
+			return rop.Step(ctx, "orswitch", input, rop.Carry(r, rop.Fail[Out](r.Err())))
 		}
 	}
 
-	return switchF(ctx, input)
+	return switchF(ctx, rop.Step(ctx, "orswitch", input, input))
+}
+
+// member runs f, a member of a group, through the interceptors of ctx.
+func member[In any](ctx context.Context, input rop.Result[In],
+	f func(ctx context.Context, in rop.Result[In]) rop.Result[In]) rop.Result[In] {
+
+	return rop.Intercept(ctx, "group", input, func(ctx context.Context) rop.Result[In] {
+		return f(ctx, input)
+	})
+}
+
+// alternative runs f, an alternative of an Or group, through the
+// interceptors of ctx. An alternative they did not let run is not accepted.
+func alternative[In any](ctx context.Context, input rop.Result[In],
+	f func(ctx context.Context, in rop.Result[In]) (accepted bool, res rop.Result[In])) (bool, rop.Result[In]) {
+
+	accepted := false
+	res := rop.Intercept(ctx, "group", input, func(ctx context.Context) rop.Result[In] {
+		var r rop.Result[In]
+		accepted, r = f(ctx, input)
+		return r
+	})
+	return accepted, res
 }
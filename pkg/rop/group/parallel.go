@@ -0,0 +1,206 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"sync"
+)
+
+// ErrSiblingFailed is the cancel cause seen by the functions still running
+// when a sibling fails under fail-fast.
+var ErrSiblingFailed = errors.New("sibling failed")
+
+// Parallel configures the parallel group operators.
+type Parallel struct {
+	// Limit is the maximum number of functions running at once, <= 0 means no limit.
+	Limit int
+	// CollectAll runs every function and joins all errors instead of
+	// cancelling the siblings on the first failure.
+	CollectAll bool
+}
+
+// AndTeeParallel runs the independent checks fs concurrently, each of them
+// with the input. When all of them succeed the input is returned. Otherwise
+// the result is the first failure or, with CollectAll, a rop.Fail joining
+// the errors of all failed and cancelled functions in the order of fs; it is
+// cancelled only if none of them failed.
+func AndTeeParallel[In any](ctx context.Context, input rop.Result[In], p Parallel,
+	fs ...func(ctx context.Context, in rop.Result[In]) rop.Result[In]) rop.Result[In] {
+
+	if !input.IsSuccess() || len(fs) == 0 {
+		return input
+	}
+
+	return rop.Step(ctx, "andtee", input, runParallel(ctx, input, p, fs))
+}
+
+func AndSwitchParallel[In, Out any](ctx context.Context, input rop.Result[In],
+	switchF func(ctx context.Context, r rop.Result[In]) rop.Result[Out], p Parallel,
+	fs ...func(ctx context.Context, in rop.Result[In]) rop.Result[In]) rop.Result[Out] {
+
+	if !input.IsSuccess() {
+		return switchF(ctx, input)
+	}
+
+	if res := runParallel(ctx, input, p, fs); !res.IsSuccess() {
+		return rop.Step(ctx, "andswitch", input, passOn[In, Out](res))
+	}
+
+	return switchF(ctx, rop.Step(ctx, "andswitch", input, input))
+}
+
+// OrSwitchParallel evaluates all alternatives fs concurrently with the input
+// and continues like OrSwitchWithCtx with the first of them, in the order of
+// fs, that accepted it. Use it only for alternatives without side effects:
+// every one of them runs. CollectAll is ignored.
+func OrSwitchParallel[In, Out any](ctx context.Context, input rop.Result[In],
+	switchF func(ctx context.Context, r rop.Result[In]) rop.Result[Out], p Parallel,
+	fs ...func(ctx context.Context, in rop.Result[In]) (accepted bool, res rop.Result[In])) rop.Result[Out] {
+
+	if !input.IsSuccess() {
+		return switchF(ctx, input)
+	}
+
+	limit := p.Limit
+	if limit <= 0 || limit > len(fs) {
+		limit = len(fs)
+	}
+	sem := make(chan struct{}, limit)
+
+	accepted := make([]bool, len(fs))
+	results := make([]rop.Result[In], len(fs))
+
+	var wg sync.WaitGroup
+	for i, f := range fs {
+		sem <- struct{}{}
+
+		wg.Add(1)
+		go func(i int, f func(ctx context.Context, in rop.Result[In]) (bool, rop.Result[In])) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			returned := false
+			results[i] = solo.Protect(func() rop.Result[In] {
+				ok, res := alternative(ctx, input, f)
+				accepted[i], returned = ok, true
+				return res
+			})
+			accepted[i] = accepted[i] || !returned // a panic is taken as a failed acceptance
+		}(i, f)
+	}
+	wg.Wait()
+
+	for i, r := range results {
+		if !accepted[i] {
+			continue
+		}
+		if r.IsSuccess() {
+			return switchF(ctx, rop.Step(ctx, "orswitch", input, r))
+		}
+		return rop.Step(ctx, "orswitch", input, passOn[In, Out](r))
+	}
+
+	return switchF(ctx, rop.Step(ctx, "orswitch", input, input))
+}
+
+func runParallel[In any](ctx context.Context, input rop.Result[In], p Parallel,
+	fs []func(ctx context.Context, in rop.Result[In]) rop.Result[In]) rop.Result[In] {
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	limit := p.Limit
+	if limit <= 0 || limit > len(fs) {
+		limit = len(fs)
+	}
+	sem := make(chan struct{}, limit)
+
+	results := make([]rop.Result[In], len(fs))
+	var mu sync.Mutex
+	first := -1
+
+	var wg sync.WaitGroup
+start:
+	for i, f := range fs {
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break start
+		}
+
+		if !p.CollectAll && ctx.Err() != nil {
+			<-sem
+			break start
+		}
+
+		wg.Add(1)
+		go func(i int, f func(ctx context.Context, in rop.Result[In]) rop.Result[In]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res := solo.Protect(func() rop.Result[In] {
+				return member(ctx, input, f)
+			})
+			results[i] = res
+
+			if res.IsSuccess() {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if first < 0 {
+				first = i
+				if !p.CollectAll {
+					cancel(ErrSiblingFailed)
+				}
+			}
+		}(i, f)
+	}
+	wg.Wait()
+
+	if first < 0 {
+		if err := context.Cause(ctx); err != nil { // the parent was cancelled
+			return rop.Carry(input, rop.CancelFromCtx[In](ctx, err))
+		}
+		return input
+	}
+
+	if !p.CollectAll {
+		return rop.Carry(input, results[first])
+	}
+	return rop.Carry(input, collect(results, first))
+}
+
+func collect[T any](results []rop.Result[T], first int) rop.Result[T] {
+
+	var errs []error
+	failed := false
+	for _, res := range results {
+		if res.IsSuccess() || res.IsFiltered() || res.Err() == nil {
+			continue
+		}
+		failed = failed || !res.IsCancel()
+		errs = append(errs, res.Err())
+	}
+
+	switch {
+	case failed:
+		return rop.Fail[T](errors.Join(errs...))
+	case len(errs) > 0:
+		return rop.Cancel[T](errors.Join(errs...))
+	default:
+		return results[first] // only filtered
+	}
+}
+
+func passOn[In, Out any](input rop.Result[In]) rop.Result[Out] {
+	if input.IsCancel() {
+		return rop.Carry(input, rop.Cancel[Out](input.Err()))
+	} else {
+		return rop.Carry(input, rop.Fail[Out](input.Err()))
+	}
+}
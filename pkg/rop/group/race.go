@@ -0,0 +1,199 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"time"
+)
+
+var (
+	// ErrNotNeeded is the cancel cause seen by alternatives whose result is
+	// no longer needed because the race or the quorum has been decided.
+	ErrNotNeeded      = errors.New("result no longer needed")
+	ErrNoQuorum       = errors.New("quorum not reached")
+	ErrNoAlternatives = errors.New("no alternatives")
+)
+
+type answer[Out any] struct {
+	index int
+	res   rop.Result[Out]
+}
+
+// Race runs the alternatives fs concurrently. The first success wins and the
+// others are cancelled through ctx. If none succeeds the errors are joined as
+// in AndTeeParallel with CollectAll.
+func Race[In, Out any](ctx context.Context, input rop.Result[In],
+	fs ...func(ctx context.Context, r In) rop.Result[Out]) rop.Result[Out] {
+
+	if !input.IsSuccess() {
+		return passOn[In, Out](input)
+	}
+	return rop.Step(ctx, "race", input, hedge(ctx, input, 0, fs))
+}
+
+// Hedged starts fs[0] and, each time delay passes without a success, the
+// next alternative, so a slow call gets backed up by another one. A failure
+// starts the next alternative at once. The first success wins and the
+// alternatives still running are cancelled. A delay <= 0 starts all of them
+// at once, like Race.
+func Hedged[In, Out any](ctx context.Context, input rop.Result[In], delay time.Duration,
+	fs ...func(ctx context.Context, r In) rop.Result[Out]) rop.Result[Out] {
+
+	if !input.IsSuccess() {
+		return passOn[In, Out](input)
+	}
+	return rop.Step(ctx, "hedged", input, hedge(ctx, input, delay, fs))
+}
+
+func hedge[In, Out any](ctx context.Context, input rop.Result[In], delay time.Duration,
+	fs []func(ctx context.Context, r In) rop.Result[Out]) rop.Result[Out] {
+
+	if len(fs) == 0 {
+		return rop.Carry(input, rop.Fail[Out](ErrNoAlternatives))
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(ErrNotNeeded)
+
+	answers := make(chan answer[Out], len(fs)) // losers must not block
+	results := make([]rop.Result[Out], len(fs))
+	started, running, first := 0, 0, -1
+
+	launch := func() {
+		go call(ctx, started, input, fs[started], answers)
+		started++
+		running++
+	}
+
+	hedge := time.NewTimer(delay)
+	defer hedge.Stop()
+
+	launch()
+	for delay <= 0 && started < len(fs) {
+		launch()
+	}
+
+	for {
+		var hedgeC <-chan time.Time
+		if started < len(fs) {
+			hedgeC = hedge.C
+		}
+
+		select {
+		case a := <-answers:
+			running--
+			if a.res.IsSuccess() {
+				return rop.Carry(input, a.res)
+			}
+
+			results[a.index] = a.res
+			if first < 0 {
+				first = a.index
+			}
+
+			if running > 0 {
+				continue
+			}
+			if started == len(fs) {
+				return rop.Carry(input, collect(results, first))
+			}
+			launch()
+
+		case <-hedgeC:
+			launch()
+
+		case <-ctx.Done():
+			return rop.Carry(input, rop.CancelFromCtx[Out](ctx, ctx.Err()))
+		}
+
+		resetTimer(hedge, delay)
+	}
+}
+
+// Quorum runs fs concurrently and succeeds with the values of the first k
+// successful alternatives, in the order of fs, cancelling the rest. It fails
+// with ErrNoQuorum as soon as k successes are out of reach.
+func Quorum[In, Out any](ctx context.Context, input rop.Result[In], k int,
+	fs ...func(ctx context.Context, r In) rop.Result[Out]) rop.Result[[]Out] {
+
+	if !input.IsSuccess() {
+		return passOn[In, []Out](input)
+	}
+	return rop.Step(ctx, "quorum", input, quorum(ctx, input, k, fs))
+}
+
+func quorum[In, Out any](ctx context.Context, input rop.Result[In], k int,
+	fs []func(ctx context.Context, r In) rop.Result[Out]) rop.Result[[]Out] {
+
+	if k <= 0 {
+		return rop.Carry(input, rop.Success([]Out{}))
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(ErrNotNeeded)
+
+	answers := make(chan answer[Out], len(fs))
+	for i, f := range fs {
+		go call(ctx, i, input, f, answers)
+	}
+
+	succeeded := make([]*Out, len(fs))
+	successes := 0
+	var errs []error
+
+	for pending := len(fs); pending > 0 && successes < k && len(fs)-len(errs) >= k; pending-- {
+		select {
+		case a := <-answers:
+			if a.res.IsSuccess() {
+				value := a.res.Result()
+				succeeded[a.index] = &value
+				successes++
+			} else {
+				errs = append(errs, a.res.Err())
+			}
+		case <-ctx.Done():
+			return rop.Carry(input, rop.CancelFromCtx[[]Out](ctx, ctx.Err()))
+		}
+	}
+
+	if successes < k {
+		return rop.Carry(input, rop.Fail[[]Out](fmt.Errorf("%w: %d of %d succeeded, %d needed: %w",
+			ErrNoQuorum, successes, len(fs), k, errors.Join(errs...))))
+	}
+
+	values := make([]Out, 0, k)
+	for _, v := range succeeded {
+		if v != nil {
+			values = append(values, *v)
+		}
+	}
+	return rop.Carry(input, rop.Success(values))
+}
+
+// call runs the alternative f through the interceptors of ctx and sends
+// its answer.
+func call[In, Out any](ctx context.Context, index int, input rop.Result[In],
+	f func(ctx context.Context, r In) rop.Result[Out], answers chan<- answer[Out]) {
+
+	answers <- answer[Out]{
+		index: index,
+		res: solo.Protect(func() rop.Result[Out] {
+			return rop.Intercept(ctx, "group", input, func(ctx context.Context) rop.Result[Out] {
+				return f(ctx, input.Result())
+			})
+		}),
+	}
+}
+
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	t.Reset(d)
+}
@@ -1,5 +1,9 @@
 package rop
 
+import (
+	"context"
+)
+
 // WithError defines an interface for types that can return a result or an error
 type WithError[T any] interface {
 	// Result returns the successful result value
@@ -16,3 +20,33 @@ type WithCancel[T any] interface {
 	// IsCancel returns true if the operation was cancelled
 	IsCancel() bool
 }
+
+// Operator is a custom per-item stage: Apply transforms a result and Cancel
+// reports a result that reaches the stage after the context is done. Lift it
+// with solo.Lift, mass.Lift or bridge.Lift to use it in any mode.
+type Operator[In, Out any] interface {
+	// Apply transforms the input result
+	Apply(ctx context.Context, in Result[In]) Result[Out]
+	// Cancel turns the input result into the cancelled output
+	Cancel(ctx context.Context, in Result[In]) Result[Out]
+}
+
+// NewOperator builds an Operator from its two functions.
+func NewOperator[In, Out any](applyF func(ctx context.Context, in Result[In]) Result[Out],
+	cancelF func(ctx context.Context, in Result[In]) Result[Out]) Operator[In, Out] {
+
+	return operator[In, Out]{applyF: applyF, cancelF: cancelF}
+}
+
+type operator[In, Out any] struct {
+	applyF  func(ctx context.Context, in Result[In]) Result[Out]
+	cancelF func(ctx context.Context, in Result[In]) Result[Out]
+}
+
+func (o operator[In, Out]) Apply(ctx context.Context, in Result[In]) Result[Out] {
+	return o.applyF(ctx, in)
+}
+
+func (o operator[In, Out]) Cancel(ctx context.Context, in Result[In]) Result[Out] {
+	return o.cancelF(ctx, in)
+}
@@ -0,0 +1,103 @@
+package rop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const InterceptorsKey = "interceptors"
+
+// ErrInterceptor fails a call whose interceptor returned nil or a success
+// of another type.
+var ErrInterceptor = errors.New("interceptor returned an unusable outcome")
+
+// Outcome is the track of a Result of any type.
+type Outcome interface {
+	IsSuccess() bool
+	IsCancel() bool
+	IsFiltered() bool
+	Err() error
+}
+
+// Call describes a user function invoked by a stage.
+type Call struct {
+	// Stage is the name given with WithStage, if any
+	Stage string
+	// Kind is the kind of the stage: validate, switch, map, try, tee...
+	Kind string
+	// Input is the Result the stage was given
+	Input Outcome
+}
+
+// Interceptor runs around every user function a stage calls. It runs the
+// function by calling next, possibly with a derived context, and returns
+// the output Result or an outcome of its own. An outcome that is not a
+// Result of the stage's type keeps only its track and error.
+type Interceptor func(ctx context.Context, call Call, next func(ctx context.Context) Outcome) Outcome
+
+// WithInterceptors registers interceptors for the stages that run with the
+// returned context, inside the ones registered before.
+func WithInterceptors(ctx context.Context, interceptors ...Interceptor) context.Context {
+	registered := GetInterceptorsFromCtx(ctx)
+	return context.WithValue(ctx, InterceptorsKey,
+		append(registered[:len(registered):len(registered)], interceptors...))
+}
+
+func GetInterceptorsFromCtx(ctx context.Context) []Interceptor {
+	interceptors, _ := ctx.Value(InterceptorsKey).([]Interceptor)
+	return interceptors
+}
+
+// Intercept runs f, the call of a user function by a stage of the given
+// kind, through the interceptors of ctx.
+func Intercept[In, Out any](ctx context.Context, kind string, input Result[In],
+	f func(ctx context.Context) Result[Out]) Result[Out] {
+
+	interceptors := GetInterceptorsFromCtx(ctx)
+	if len(interceptors) == 0 {
+		return f(ctx)
+	}
+
+	stage, _ := GetStageFromCtx(ctx)
+	call := Call{Stage: stage, Kind: kind, Input: input}
+
+	next := func(ctx context.Context) Outcome {
+		return f(ctx)
+	}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := interceptors[i], next
+		next = func(ctx context.Context) Outcome {
+			return interceptor(ctx, call, inner)
+		}
+	}
+
+	return asResult[Out](next(ctx))
+}
+
+// Invoke intercepts f like Intercept and records the stage like Step.
+func Invoke[In, Out any](ctx context.Context, kind string, input Result[In],
+	f func(ctx context.Context) Result[Out]) Result[Out] {
+
+	return Step(ctx, kind, input, Intercept(ctx, kind, input, f))
+}
+
+func asResult[Out any](o Outcome) Result[Out] {
+
+	if r, ok := o.(Result[Out]); ok {
+		return r
+	}
+
+	switch {
+	case o == nil:
+		return Fail[Out](fmt.Errorf("%w: nil", ErrInterceptor))
+	case o.IsSuccess():
+		return Fail[Out](fmt.Errorf("%w: %T", ErrInterceptor, o))
+	case o.IsFiltered():
+		return Filtered[Out](o.Err())
+	case o.IsCancel():
+		return Cancel[Out](o.Err())
+	default:
+		return Fail[Out](o.Err())
+	}
+}
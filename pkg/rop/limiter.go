@@ -0,0 +1,124 @@
+package rop
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock is the time source of a Limiter. Tests can inject a manual one.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+var SystemClock Clock = systemClock{}
+
+// Limiter is a token bucket refilled at a fixed rate up to burst tokens.
+// It is safe for concurrent use, so one Limiter can be shared between stages
+// and bridge lanes.
+type Limiter struct {
+	mu     sync.Mutex
+	clock  Clock
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter allows perSecond events per second with bursts of up to burst
+// events. A nil clock means SystemClock; perSecond <= 0 disables limiting.
+func NewLimiter(perSecond float64, burst int, clock Clock) *Limiter {
+	if clock == nil {
+		clock = SystemClock
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		clock:  clock,
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   clock.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done. In the latter case
+// the token is given back and ctx.Err() is returned.
+func (l *Limiter) Wait(ctx context.Context) error {
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	select {
+	case <-l.clock.After(delay):
+		return nil
+	case <-ctx.Done():
+		l.release()
+		return ctx.Err()
+	}
+}
+
+// Allow takes a token if one is available right now.
+func (l *Limiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate <= 0 {
+		return true
+	}
+
+	l.refill(l.clock.Now())
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.refill(l.clock.Now())
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+func (l *Limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = min(l.burst, l.tokens+1)
+}
+
+func (l *Limiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+}
@@ -0,0 +1,229 @@
+package logging
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const Message = "rop result"
+
+// Levels are the levels the results of each track are logged at.
+type Levels struct {
+	Success  slog.Level
+	Fail     slog.Level
+	Cancel   slog.Level
+	Filtered slog.Level
+}
+
+// DefaultLevels log failures as errors, cancellations as warnings and the
+// rest as debug records.
+var DefaultLevels = Levels{
+	Success:  slog.LevelDebug,
+	Fail:     slog.LevelError,
+	Cancel:   slog.LevelWarn,
+	Filtered: slog.LevelDebug,
+}
+
+type Option func(o *options)
+
+type options struct {
+	logger  *slog.Logger
+	levels  Levels
+	every   int64
+	limiter *rop.Limiter
+	redact  func(a slog.Attr) slog.Attr
+}
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+func WithLevels(levels Levels) Option {
+	return func(o *options) {
+		o.levels = levels
+	}
+}
+
+// WithSampling logs only every n-th failed or cancelled result.
+func WithSampling(n int) Option {
+	return func(o *options) {
+		o.every = int64(n)
+	}
+}
+
+// WithRateLimit logs failed and cancelled results only while limiter has
+// tokens, without ever waiting for one.
+func WithRateLimit(limiter *rop.Limiter) Option {
+	return func(o *options) {
+		o.limiter = limiter
+	}
+}
+
+// WithRedact passes every error and metadata attribute through redactF
+// before it is logged, e.g. to mask secrets.
+func WithRedact(redactF func(a slog.Attr) slog.Attr) Option {
+	return func(o *options) {
+		o.redact = redactF
+	}
+}
+
+// Logger logs results with their track, error, stage and metadata. Failed
+// and cancelled results are subject to sampling and rate limiting; the
+// next record logged tells how many of them were suppressed. A Logger is
+// safe for concurrent use.
+type Logger struct {
+	o options
+
+	mu         sync.Mutex
+	failures   int64
+	suppressed int64
+}
+
+func New(opts ...Option) *Logger {
+	o := options{
+		levels: DefaultLevels,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.logger == nil {
+		o.logger = slog.Default()
+	}
+	return &Logger{o: o}
+}
+
+// Log logs the result r of the stage named in ctx.
+func Log[T any](ctx context.Context, l *Logger, r rop.Result[T]) {
+	stage, _ := rop.GetStageFromCtx(ctx)
+	l.log(ctx, r, slog.String("stage", stage))
+}
+
+// Tee logs the input and passes it on.
+func Tee[T any](ctx context.Context, l *Logger, input rop.Result[T]) rop.Result[T] {
+	Log(ctx, l, input)
+	return input
+}
+
+// Operator logs every result that passes, cancelled items included; once
+// the stream is cancelled successful items are logged and passed on as
+// cancelled. Lift it into a stream with mass.Lift, bridge.Lift or
+// pipeline.Lift.
+func Operator[T any](l *Logger) rop.Operator[T, T] {
+	return rop.NewOperator(
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return Tee(ctx, l, in)
+		},
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			if in.IsSuccess() {
+				in = rop.Carry(in, rop.CancelFromCtx[T](ctx, ctx.Err()))
+			}
+			return Tee(ctx, l, in)
+		})
+}
+
+// Interceptor logs the output of every user function a stage calls with the
+// stage name, its kind and the duration of the call.
+func (l *Logger) Interceptor() rop.Interceptor {
+	return func(ctx context.Context, call rop.Call,
+		next func(ctx context.Context) rop.Outcome) rop.Outcome {
+
+		started := time.Now()
+		out := next(ctx)
+
+		if out != nil {
+			l.log(ctx, out,
+				slog.String("stage", call.Stage),
+				slog.String("kind", call.Kind),
+				slog.Duration("duration", time.Since(started)))
+		}
+		return out
+	}
+}
+
+func (l *Logger) log(ctx context.Context, o rop.Outcome, attrs ...slog.Attr) {
+
+	state, level := l.track(o)
+	if !l.o.logger.Enabled(ctx, level) {
+		return
+	}
+
+	if state == "fail" || state == "cancel" {
+		suppressed, ok := l.admit()
+		if !ok {
+			return
+		}
+		if suppressed > 0 {
+			attrs = append(attrs, slog.Int64("suppressed", suppressed))
+		}
+	}
+
+	attrs = append(attrs, slog.String("state", state))
+
+	if err := o.Err(); err != nil && state != "success" {
+		attrs = append(attrs, l.redact(slog.String("error", err.Error())))
+	}
+	if r, ok := o.(interface{ CancelReason() rop.CancelReason }); ok && state == "cancel" {
+		attrs = append(attrs, slog.String("reason", r.CancelReason().String()))
+	}
+	if r, ok := o.(interface{ Trail() []string }); ok {
+		if trail := r.Trail(); len(trail) > 0 {
+			attrs = append(attrs, slog.Any("trail", trail))
+		}
+	}
+	if r, ok := o.(interface {
+		EachMeta(f func(name string, value any))
+	}); ok {
+		meta := make([]any, 0)
+		r.EachMeta(func(name string, value any) {
+			meta = append(meta, l.redact(slog.Any(name, value)))
+		})
+		if len(meta) > 0 {
+			attrs = append(attrs, slog.Group("meta", meta...))
+		}
+	}
+
+	l.o.logger.LogAttrs(ctx, level, Message, attrs...)
+}
+
+func (l *Logger) track(o rop.Outcome) (string, slog.Level) {
+	switch {
+	case o.IsSuccess():
+		return "success", l.o.levels.Success
+	case o.IsFiltered():
+		return "filtered", l.o.levels.Filtered
+	case o.IsCancel():
+		return "cancel", l.o.levels.Cancel
+	default:
+		return "fail", l.o.levels.Fail
+	}
+}
+
+// admit applies sampling and rate limiting to a failure and returns how many
+// failures were suppressed since the last one logged.
+func (l *Logger) admit() (int64, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.failures++
+	sampled := l.o.every <= 1 || (l.failures-1)%l.o.every == 0
+	if !sampled || (l.o.limiter != nil && !l.o.limiter.Allow()) {
+		l.suppressed++
+		return 0, false
+	}
+
+	suppressed := l.suppressed
+	l.suppressed = 0
+	return suppressed, true
+}
+
+func (l *Logger) redact(a slog.Attr) slog.Attr {
+	if l.o.redact == nil {
+		return a
+	}
+	return l.o.redact(a)
+}
@@ -0,0 +1,24 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+)
+
+// Using gives every successful item its own resource, see solo.Using.
+func Using[In any, R any, Out any](ctx context.Context, inputs <-chan rop.Result[In],
+	acquireF func(ctx context.Context, r In) (R, error),
+	releaseF func(ctx context.Context, r R) error,
+	bodyF func(ctx context.Context, in In, r R) rop.Result[Out],
+	cancelF func(ctx context.Context, r In) error,
+	opts ...Option) <-chan rop.Result[Out] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return solo.Using(ctx, in, acquireF, releaseF, bodyF)
+		},
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return solo.CancelWithCtx[In, Out](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
+}
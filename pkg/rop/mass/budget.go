@@ -0,0 +1,157 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+)
+
+var ErrBudgetExceeded = errors.New("error budget exceeded")
+
+// DefaultMinSamples is how many results a MaxFailRatio waits for unless
+// Budget.MinSamples says otherwise.
+const DefaultMinSamples = 20
+
+type Threshold string
+
+const (
+	ThresholdRatio       Threshold = "ratio"
+	ThresholdConsecutive Threshold = "consecutive"
+	ThresholdTotal       Threshold = "total"
+)
+
+// Budget sets the failure thresholds watched by ErrorBudget. Zero values
+// disable the corresponding threshold.
+type Budget struct {
+	// MaxFailRatio trips once the share of failed results exceeds it, 0.05 for 5%.
+	// It applies only after MinSamples results, so a single early failure
+	// does not trip it.
+	MaxFailRatio float64
+	// MinSamples is how many results must be seen before MaxFailRatio
+	// applies, DefaultMinSamples if 0.
+	MinSamples int
+	// MaxConsecutive trips once this many failures arrive in a row.
+	MaxConsecutive int
+	// MaxFailures trips once this many failures arrive overall.
+	MaxFailures int
+}
+
+// BudgetError is the cancel cause reported by ErrorBudget. It matches
+// ErrBudgetExceeded with errors.Is.
+type BudgetError struct {
+	Threshold   Threshold
+	Seen        int
+	Failures    int
+	Consecutive int
+	Budget      Budget
+}
+
+func (e *BudgetError) Error() string {
+	switch e.Threshold {
+	case ThresholdRatio:
+		return fmt.Sprintf("%v: %d of %d results failed (%.2f%% > %.2f%%)", ErrBudgetExceeded,
+			e.Failures, e.Seen, 100*float64(e.Failures)/float64(e.Seen), 100*e.Budget.MaxFailRatio)
+	case ThresholdConsecutive:
+		return fmt.Sprintf("%v: %d consecutive failures (limit %d)", ErrBudgetExceeded,
+			e.Consecutive, e.Budget.MaxConsecutive)
+	default:
+		return fmt.Sprintf("%v: %d failures (limit %d)", ErrBudgetExceeded,
+			e.Failures, e.Budget.MaxFailures)
+	}
+}
+
+func (e *BudgetError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
+
+func (e *BudgetError) CancelReason() rop.CancelReason {
+	return rop.CancelBreaker
+}
+
+// ErrorBudget passes results through and counts the failed ones, cancelled
+// and filtered results are not counted. When a threshold of budget is
+// crossed, stop is called with a *BudgetError, so upstream stages sharing
+// its context report the remaining items through their cancel path.
+// ErrorBudget panics if stop is nil.
+func ErrorBudget[T any](ctx context.Context, inputs <-chan rop.Result[T], budget Budget,
+	stop context.CancelCauseFunc, opts ...Option) <-chan rop.Result[T] {
+
+	if stop == nil {
+		panic("mass.ErrorBudget: stop must not be nil")
+	}
+
+	o := newOptions(opts)
+	out := make(chan rop.Result[T])
+	ctx = stageCtx(ctx, o)
+
+	go func(ctx context.Context, inputs <-chan rop.Result[T]) {
+		defer discard(inputs, o)
+		defer close(out)
+
+		var tripped bool
+		var state BudgetError
+		state.Budget = budget
+
+		for {
+			in, ok := next(ctx, inputs, o)
+			if !ok {
+				return
+			}
+
+			if !tripped && !in.IsCancel() && !in.IsFiltered() {
+				state.count(in.IsSuccess())
+
+				if threshold, ok := budget.exceeded(state); ok {
+					tripped = true
+					state.Threshold = threshold
+					cause := state
+					stop(&cause)
+				}
+			}
+
+			if in.IsSuccess() {
+				in = rop.Step(ctx, "errorbudget", in, in)
+			}
+
+			if !emit(ctx, out, in, o) {
+				return
+			}
+		}
+	}(ctx, inputs)
+
+	return out
+}
+
+func (e *BudgetError) count(success bool) {
+	e.Seen++
+	if success {
+		e.Consecutive = 0
+		return
+	}
+	e.Failures++
+	e.Consecutive++
+}
+
+func (b Budget) exceeded(state BudgetError) (Threshold, bool) {
+
+	if b.MaxConsecutive > 0 && state.Consecutive >= b.MaxConsecutive {
+		return ThresholdConsecutive, true
+	}
+
+	if b.MaxFailures > 0 && state.Failures >= b.MaxFailures {
+		return ThresholdTotal, true
+	}
+
+	minSamples := b.MinSamples
+	if minSamples <= 0 {
+		minSamples = DefaultMinSamples
+	}
+
+	if b.MaxFailRatio > 0 && state.Seen >= minSamples &&
+		float64(state.Failures) > b.MaxFailRatio*float64(state.Seen) {
+		return ThresholdRatio, true
+	}
+
+	return "", false
+}
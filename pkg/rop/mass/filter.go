@@ -0,0 +1,61 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+)
+
+// Filter filters out the successful results rejected by keepF. Filtered
+// results pass the following stages untouched and are dropped by Finally.
+func Filter[T any](ctx context.Context, inputs <-chan rop.Result[T],
+	keepF func(ctx context.Context, r T) bool,
+	cancelF func(ctx context.Context, r T) error,
+	opts ...Option) <-chan rop.Result[T] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return solo.FilterWithCtx(ctx, in, keepF)
+		},
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return CancelIfPossibleWithCtx[T](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
+}
+
+// Counts tallies the results of a stream by track.
+type Counts struct {
+	Success  int
+	Fail     int
+	Cancel   int
+	Filtered int
+}
+
+func (c Counts) Total() int {
+	return c.Success + c.Fail + c.Cancel + c.Filtered
+}
+
+func (c *Counts) Add(track interface {
+	IsSuccess() bool
+	IsCancel() bool
+	IsFiltered() bool
+}) {
+	switch {
+	case track.IsSuccess():
+		c.Success++
+	case track.IsCancel():
+		c.Cancel++
+	case track.IsFiltered():
+		c.Filtered++
+	default:
+		c.Fail++
+	}
+}
+
+// Count drains inputs and returns how many results arrived on each track.
+func Count[T any](inputs <-chan rop.Result[T]) Counts {
+	var c Counts
+	for in := range inputs {
+		c.Add(in)
+	}
+	return c
+}
@@ -0,0 +1,72 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/group"
+	"github.com/ib-77/rop/pkg/rop/solo"
+)
+
+// AndTee passes on the successful results accepted by all validators fs, see
+// group.AndTeeWithCtx. With InParallel the validators of an item run
+// concurrently as in group.AndTeeParallel.
+func AndTee[T any](ctx context.Context, inputs <-chan rop.Result[T],
+	fs []func(ctx context.Context, in rop.Result[T]) rop.Result[T],
+	cancelF func(ctx context.Context, r T) error,
+	opts ...Option) <-chan rop.Result[T] {
+
+	o := newOptions(opts)
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			if !in.IsSuccess() {
+				return in
+			}
+			return rop.Invoke(ctx, "andtee", in, func(ctx context.Context) rop.Result[T] {
+				if o.parallel != nil {
+					return group.AndTeeParallel(ctx, in, *o.parallel, fs...)
+				}
+				return group.AndTeeWithCtx(ctx, in, fs...)
+			})
+		},
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return CancelIfPossibleWithCtx[T](ctx, in, cancelF)
+		}, failOnPanic, o)
+}
+
+// OrSwitch hands every successful result to the first of the alternatives fs
+// accepting it and switches the outcome with switchF, see
+// group.OrSwitchWithCtx. With InParallel all alternatives of an item are
+// evaluated concurrently as in group.OrSwitchParallel.
+func OrSwitch[In any, Out any](ctx context.Context, inputs <-chan rop.Result[In],
+	fs []func(ctx context.Context, in rop.Result[In]) (accepted bool, res rop.Result[In]),
+	switchF func(ctx context.Context, r In) rop.Result[Out],
+	cancelF func(ctx context.Context, r In) error,
+	opts ...Option) <-chan rop.Result[Out] {
+
+	o := newOptions(opts)
+
+	switchR := func(ctx context.Context, r rop.Result[In]) rop.Result[Out] {
+		if !r.IsSuccess() {
+			return solo.SwitchWithCtx(ctx, r, switchF)
+		}
+		// runs within the orswitch call of the item, no switch step of its own
+		return rop.Carry(r, switchF(rop.WithResultOf(ctx, r), r.Result()))
+	}
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			if !in.IsSuccess() {
+				return solo.SwitchWithCtx(ctx, in, switchF)
+			}
+			return rop.Invoke(ctx, "orswitch", in, func(ctx context.Context) rop.Result[Out] {
+				if o.parallel != nil {
+					return group.OrSwitchParallel(ctx, in, switchR, *o.parallel, fs...)
+				}
+				return group.OrSwitchWithCtx(ctx, in, switchR, fs...)
+			})
+		},
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return solo.CancelWithCtx[In, Out](ctx, in, cancelF)
+		}, failOnPanic, o)
+}
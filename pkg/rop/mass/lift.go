@@ -0,0 +1,24 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+)
+
+// Lift turns the custom stage op into a mass stage with the usual options,
+// cancel policy and panic recovery.
+func Lift[In, Out any](ctx context.Context, inputs <-chan rop.Result[In],
+	op rop.Operator[In, Out], opts ...Option) <-chan rop.Result[Out] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return rop.Invoke(ctx, "lift", in, func(ctx context.Context) rop.Result[Out] {
+				return op.Apply(ctx, in)
+			})
+		},
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return rop.Intercept(ctx, "cancel", in, func(ctx context.Context) rop.Result[Out] {
+				return op.Cancel(ctx, in)
+			})
+		}, failOnPanic, newOptions(opts))
+}
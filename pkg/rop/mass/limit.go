@@ -0,0 +1,195 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+)
+
+// Take passes results through until n successful results have been emitted.
+// Failed and cancelled results are forwarded but not counted. Once the limit
+// is reached stop is called, so upstream stages sharing its context switch to
+// their cancel path, and the rest of inputs is drained in the background.
+//
+// stop must cancel the context of the upstream stages, Take panics if it is
+// nil. Stages after Take must not run under that context, otherwise the last
+// taken items may land on their cancel path.
+func Take[T any](ctx context.Context, inputs <-chan rop.Result[T], n int,
+	stop context.CancelFunc, opts ...Option) <-chan rop.Result[T] {
+
+	mustStop(stop, "Take")
+	o := newOptions(opts)
+	out := make(chan rop.Result[T])
+	ctx = stageCtx(ctx, o)
+
+	go func(ctx context.Context, inputs <-chan rop.Result[T]) {
+		defer discard(inputs, o)
+		defer close(out)
+
+		if n <= 0 {
+			stop()
+			return
+		}
+
+		taken := 0
+		for {
+			in, ok := next(ctx, inputs, o)
+			if !ok {
+				return
+			}
+
+			if in.IsSuccess() {
+				in = rop.Step(ctx, "take", in, in)
+			}
+
+			if !emit(ctx, out, in, o) {
+				return
+			}
+
+			if in.IsSuccess() {
+				taken++
+				if taken >= n {
+					stop()
+					return
+				}
+			}
+		}
+	}(ctx, inputs)
+
+	return out
+}
+
+// TakeWhile passes results through while whileF holds for successful values.
+// The first successful value rejected by whileF is dropped, stop is called
+// and the rest of inputs is drained in the background. stop is required as
+// for Take.
+func TakeWhile[T any](ctx context.Context, inputs <-chan rop.Result[T],
+	whileF func(ctx context.Context, r T) bool, stop context.CancelFunc,
+	opts ...Option) <-chan rop.Result[T] {
+
+	mustStop(stop, "TakeWhile")
+	o := newOptions(opts)
+	out := make(chan rop.Result[T])
+	ctx = stageCtx(ctx, o)
+
+	go func(ctx context.Context, inputs <-chan rop.Result[T]) {
+		defer discard(inputs, o)
+		defer close(out)
+
+		for {
+			in, ok := next(ctx, inputs, o)
+			if !ok {
+				return
+			}
+
+			if in.IsSuccess() {
+				var holds bool
+				if in, holds = while(ctx, "takewhile", in, whileF); !holds {
+					stop()
+					return
+				}
+			}
+
+			if !emit(ctx, out, in, o) {
+				return
+			}
+		}
+	}(ctx, inputs)
+
+	return out
+}
+
+// Skip drops the first n successful results. Failed and cancelled results are
+// always forwarded.
+func Skip[T any](ctx context.Context, inputs <-chan rop.Result[T], n int,
+	opts ...Option) <-chan rop.Result[T] {
+
+	o := newOptions(opts)
+	out := make(chan rop.Result[T])
+	ctx = stageCtx(ctx, o)
+
+	go func(ctx context.Context, inputs <-chan rop.Result[T]) {
+		defer discard(inputs, o)
+		defer close(out)
+
+		skipped := 0
+		for {
+			in, ok := next(ctx, inputs, o)
+			if !ok {
+				return
+			}
+
+			if in.IsSuccess() {
+				if skipped < n {
+					skipped++
+					continue
+				}
+				in = rop.Step(ctx, "skip", in, in)
+			}
+
+			if !emit(ctx, out, in, o) {
+				return
+			}
+		}
+	}(ctx, inputs)
+
+	return out
+}
+
+// SkipWhile drops successful results while whileF holds. Once whileF rejects
+// a value, it and everything after it are forwarded.
+func SkipWhile[T any](ctx context.Context, inputs <-chan rop.Result[T],
+	whileF func(ctx context.Context, r T) bool, opts ...Option) <-chan rop.Result[T] {
+
+	o := newOptions(opts)
+	out := make(chan rop.Result[T])
+	ctx = stageCtx(ctx, o)
+
+	go func(ctx context.Context, inputs <-chan rop.Result[T]) {
+		defer discard(inputs, o)
+		defer close(out)
+
+		skipping := true
+		for {
+			in, ok := next(ctx, inputs, o)
+			if !ok {
+				return
+			}
+
+			if skipping && in.IsSuccess() {
+				var holds bool
+				if in, holds = while(ctx, "skipwhile", in, whileF); holds {
+					continue
+				}
+				skipping = false
+			} else if in.IsSuccess() {
+				in = rop.Step(ctx, "skipwhile", in, in)
+			}
+
+			if !emit(ctx, out, in, o) {
+				return
+			}
+		}
+	}(ctx, inputs)
+
+	return out
+}
+
+// while asks whileF about the successful input on behalf of the stage.
+func while[T any](ctx context.Context, kind string, input rop.Result[T],
+	whileF func(ctx context.Context, r T) bool) (rop.Result[T], bool) {
+
+	holds := false
+	out := rop.Invoke(ctx, kind, input, func(ctx context.Context) rop.Result[T] {
+		holds = whileF(rop.WithResultOf(ctx, input), input.Result())
+		return input
+	})
+	return out, holds && out.IsSuccess()
+}
+
+// mustStop rejects a nil stop up front: the upstream would never be cancelled
+// and draining it would leak the stage goroutine.
+func mustStop(stop context.CancelFunc, stage string) {
+	if stop == nil {
+		panic("mass." + stage + ": stop must not be nil")
+	}
+}
@@ -6,152 +6,102 @@ import (
 	"github.com/ib-77/rop/pkg/rop/solo"
 )
 
-func Validate[T any](ctx context.Context, inputs <-chan T,
-	validateF func(ctx context.Context, in T) bool,
-	cancelF func(ctx context.Context, in T) error, errMsg string) <-chan rop.Result[T] {
-
-	out := make(chan rop.Result[T])
-
-	go func(ctx context.Context, inputs <-chan T) {
-		defer close(out)
+// Successes puts the inputs on the success track. Once ctx is done it keeps
+// passing them on for the next stages to report them through their cancelF.
+func Successes[T any](ctx context.Context, inputs <-chan T, opts ...Option) <-chan rop.Result[T] {
 
-		for in := range inputs {
-
-			select {
-			case <-ctx.Done():
-				out <- rop.Cancel[T](cancelF(ctx, in)) // cancel current !!!
-				ValidateCancelWithCtx(ctx, inputs, out, cancelF)
-				return
-			default:
-				out <- solo.ValidateWithCtx(ctx, in, validateF, errMsg)
-			}
-		}
-	}(ctx, inputs)
+	success := func(_ context.Context, in T) rop.Result[T] {
+		return rop.Success(in)
+	}
+	return run(ctx, inputs, success, success, failOnPanic, newOptions(opts))
+}
 
-	return out
+func Validate[T any](ctx context.Context, inputs <-chan T,
+	validateF func(ctx context.Context, in T) bool,
+	cancelF func(ctx context.Context, in T) error, errMsg string,
+	opts ...Option) <-chan rop.Result[T] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in T) rop.Result[T] {
+			return solo.ValidateWithCtx(ctx, in, validateF, errMsg)
+		},
+		func(ctx context.Context, in T) rop.Result[T] {
+			return rop.Intercept(ctx, "cancel", rop.Success(in), func(ctx context.Context) rop.Result[T] {
+				return rop.CancelFromCtx[T](ctx, cancelF(ctx, in))
+			})
+		}, failOnPanic, newOptions(opts))
 }
 
 func AndValidate[T any](ctx context.Context, inputs <-chan rop.Result[T],
 	validateF func(ctx context.Context, in T) bool,
-	cancelF func(ctx context.Context, in T) error, errMsg string) <-chan rop.Result[T] {
-
-	out := make(chan rop.Result[T])
-	go func(ctx context.Context, inputs <-chan rop.Result[T], errMsg string) {
-		defer close(out)
-
-		for in := range inputs {
-			select {
-			case <-ctx.Done():
-				out <- CancelIfPossibleWithCtx[T](ctx, in, cancelF) // cancel current !!!
-				AndValidateCancelWithCtx[T](ctx, inputs, out, cancelF)
-				return
-			default:
-				out <- solo.AndValidateWithCtx(ctx, in, validateF, errMsg)
-			}
-		}
-
-	}(ctx, inputs, errMsg)
-
-	return out
+	cancelF func(ctx context.Context, in T) error, errMsg string,
+	opts ...Option) <-chan rop.Result[T] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return solo.AndValidateWithCtx(ctx, in, validateF, errMsg)
+		},
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return CancelIfPossibleWithCtx[T](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
 }
 
 func Switch[In any, Out any](ctx context.Context, inputs <-chan rop.Result[In],
 	switchF func(ctx context.Context, r In) rop.Result[Out],
-	cancelF func(ctx context.Context, r In) error) <-chan rop.Result[Out] {
-	out := make(chan rop.Result[Out])
-
-	go func(ctx context.Context, inputs <-chan rop.Result[In]) {
-		defer close(out)
-
-		for in := range inputs {
-
-			select {
-			case <-ctx.Done():
-				out <- solo.CancelWithCtx[In, Out](ctx, in, cancelF) // cancel current !!!
-				CancelWithCtx(ctx, inputs, out, cancelF)
-				return
-			default:
-				out <- solo.SwitchWithCtx(ctx, in, switchF)
-			}
-
-		}
-	}(ctx, inputs)
-	return out
+	cancelF func(ctx context.Context, r In) error,
+	opts ...Option) <-chan rop.Result[Out] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return solo.SwitchWithCtx(ctx, in, switchF)
+		},
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return solo.CancelWithCtx[In, Out](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
 }
 
 func Map[In any, Out any](ctx context.Context, inputs <-chan rop.Result[In],
 	mapF func(ctx context.Context, r In) Out,
-	cancelF func(ctx context.Context, r In) error) <-chan rop.Result[Out] {
-
-	out := make(chan rop.Result[Out])
-
-	go func(ctx context.Context, inputs <-chan rop.Result[In]) {
-		defer close(out)
-
-		for in := range inputs {
-
-			select {
-			case <-ctx.Done():
-				out <- solo.CancelWithCtx[In, Out](ctx, in, cancelF) // cancel current !!!
-				CancelWithCtx(ctx, inputs, out, cancelF)
-				return
-			default:
-				out <- solo.MapWithCtx(ctx, in, mapF)
-			}
-
-		}
-	}(ctx, inputs)
-	return out
+	cancelF func(ctx context.Context, r In) error,
+	opts ...Option) <-chan rop.Result[Out] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return solo.MapWithCtx(ctx, in, mapF)
+		},
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return solo.CancelWithCtx[In, Out](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
 }
 
 func Tee[T any](ctx context.Context, inputs <-chan rop.Result[T],
 	deadEndF func(ctx context.Context, r rop.Result[T]),
-	cancelF func(ctx context.Context, r T) error) <-chan rop.Result[T] {
-
-	out := make(chan rop.Result[T])
-
-	go func(ctx context.Context, inputs <-chan rop.Result[T]) {
-		defer close(out)
-
-		for in := range inputs {
-
-			select {
-			case <-ctx.Done():
-				out <- solo.CancelWithCtx[T, T](ctx, in, cancelF) // cancel current !!!
-				CancelWithCtx(ctx, inputs, out, cancelF)
-				return
-			default:
-				out <- solo.TeeWithCtx(ctx, in, deadEndF)
-			}
-		}
-	}(ctx, inputs)
-	return out
+	cancelF func(ctx context.Context, r T) error,
+	opts ...Option) <-chan rop.Result[T] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return solo.TeeWithCtx(ctx, in, deadEndF)
+		},
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return solo.CancelWithCtx[T, T](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
 }
 
 func DoubleMap[In any, Out any](ctx context.Context, inputs <-chan rop.Result[In],
 	successF func(ctx context.Context, r In) Out,
 	failF func(ctx context.Context, err error) Out,
 	cancelF func(ctx context.Context, err error) Out,
-	massCancelF func(ctx context.Context, r In) error) <-chan rop.Result[Out] {
-
-	out := make(chan rop.Result[Out])
-
-	go func(ctx context.Context, inputs <-chan rop.Result[In]) {
-		defer close(out)
-
-		for in := range inputs {
-
-			select {
-			case <-ctx.Done():
-				out <- solo.CancelWithCtx[In, Out](ctx, in, massCancelF) // cancel current !!!
-				CancelWithCtx(ctx, inputs, out, massCancelF)
-				return
-			default:
-				out <- solo.DoubleMapWithCtx(ctx, in, successF, failF, cancelF)
-			}
-		}
-	}(ctx, inputs)
-	return out
+	massCancelF func(ctx context.Context, r In) error,
+	opts ...Option) <-chan rop.Result[Out] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return solo.DoubleMapWithCtx(ctx, in, successF, failF, cancelF)
+		},
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return solo.CancelWithCtx[In, Out](ctx, in, massCancelF)
+		}, failOnPanic, newOptions(opts))
 }
 
 func SucceedWith[In any, Out any](inputs <-chan rop.Result[In], outs chan rop.Result[Out],
@@ -186,7 +136,9 @@ func ValidateCancelWithCtx[In any](ctx context.Context, inputs <-chan In,
 
 	for c := range inputs {
 		// is not cancelled before
-		outs <- rop.Cancel[In](cancelF(ctx, c))
+		outs <- rop.Intercept(ctx, "cancel", rop.Success(c), func(ctx context.Context) rop.Result[In] {
+			return rop.Cancel[In](cancelF(ctx, c))
+		})
 	}
 	return outs
 }
@@ -211,7 +163,9 @@ func AndValidateCancelWithCtx[In any](ctx context.Context, inputs <-chan rop.Res
 func CancelIfPossibleWithCtx[T any](ctx context.Context, input rop.Result[T],
 	cancelF func(ctx context.Context, in T) error) rop.Result[T] {
 	if input.IsSuccess() {
-		return rop.Cancel[T](cancelF(ctx, input.Result()))
+		return rop.Intercept(ctx, "cancel", input, func(ctx context.Context) rop.Result[T] {
+			return rop.CancelFromCtx[T](ctx, cancelF(ctx, input.Result()))
+		})
 	}
 	return input
 }
@@ -226,75 +180,52 @@ func CancelIfPossible[T any](input rop.Result[T],
 
 func Try[In any, Out any](ctx context.Context, inputs <-chan rop.Result[In],
 	withErrF func(ctx context.Context, r In) (Out, error),
-	cancelF func(ctx context.Context, r In) error) <-chan rop.Result[Out] {
-
-	out := make(chan rop.Result[Out])
-
-	go func(ctx context.Context, inputs <-chan rop.Result[In]) {
-		defer close(out)
-
-		for in := range inputs {
-
-			select {
-			case <-ctx.Done():
-				out <- solo.CancelWithCtx[In, Out](ctx, in, cancelF) // cancel current !!!
-				CancelWithCtx(ctx, inputs, out, cancelF)
-				return
-			default:
-				out <- solo.TryWithCtx(ctx, in, withErrF)
-			}
-		}
-	}(ctx, inputs)
-	return out
+	cancelF func(ctx context.Context, r In) error,
+	opts ...Option) <-chan rop.Result[Out] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return solo.TryWithCtx(ctx, in, withErrF)
+		},
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return solo.CancelWithCtx[In, Out](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
 }
 
 func Check[In any](ctx context.Context, inputs <-chan rop.Result[In],
 	boolF func(ctx context.Context, r In) bool, falseErrMsg string,
-	cancelF func(ctx context.Context, r In) error) <-chan rop.Result[bool] {
-
-	out := make(chan rop.Result[bool])
-
-	go func(ctx context.Context, inputs <-chan rop.Result[In], falseErrMsg string) {
-		defer close(out)
-
-		for in := range inputs {
-
-			select {
-			case <-ctx.Done():
-				out <- solo.CancelWithCtx[In, bool](ctx, in, cancelF)
-				CheckCancelWithCtx(ctx, inputs, out, cancelF)
-				return
-			default:
-				out <- solo.CheckWithCtx(ctx, in, boolF, falseErrMsg)
-			}
-		}
-	}(ctx, inputs, falseErrMsg)
-	return out
+	cancelF func(ctx context.Context, r In) error,
+	opts ...Option) <-chan rop.Result[bool] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[In]) rop.Result[bool] {
+			return solo.CheckWithCtx(ctx, in, boolF, falseErrMsg)
+		},
+		func(ctx context.Context, in rop.Result[In]) rop.Result[bool] {
+			return solo.CancelWithCtx[In, bool](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
 }
 
+// Finally drops filtered results, everything else ends up in one of the handlers.
 func Finally[Out, In any](ctx context.Context, inputs <-chan rop.Result[In],
 	successF func(ctx context.Context, r In) Out,
 	failF func(ctx context.Context, err error) Out,
-	cancelF func(ctx context.Context, r rop.Result[In]) Out) <-chan Out {
-
-	out := make(chan Out)
-
-	go func(ctx context.Context, inputs <-chan rop.Result[In]) {
-		defer close(out)
-
-		for in := range inputs {
-
-			select {
-			case <-ctx.Done():
-				out <- cancelF(ctx, in) // cancel current !!!
-				FinallyCancelWithCtx(ctx, inputs, out, cancelF)
-				return
-			default:
-				out <- solo.FinallyWithCtx(ctx, in, successF, failF)
-			}
-		}
-	}(ctx, inputs)
-	return out
+	cancelF func(ctx context.Context, r rop.Result[In]) Out,
+	opts ...Option) <-chan Out {
+
+	o := newOptions(opts)
+	o.dropFiltered = true
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[In]) Out {
+			return solo.FinallyWithCtx(ctx, in, successF, failF)
+		},
+		func(ctx context.Context, in rop.Result[In]) Out {
+			return finallyCancel(ctx, in, cancelF)
+		},
+		func(ctx context.Context, in rop.Result[In], err error) Out {
+			return failF(ctx, err)
+		}, o)
 }
 
 func CancelWith[In any, Out any](inputs <-chan rop.Result[In], outs chan rop.Result[Out],
@@ -329,12 +260,28 @@ func FinallyCancelWithCtx[Out, In any](ctx context.Context, inputs <-chan rop.Re
 	outs chan Out, cancelF func(ctx context.Context, r rop.Result[In]) Out) <-chan Out {
 
 	for c := range inputs {
-		outs <- cancelF(ctx, c)
+		outs <- finallyCancel(ctx, c, cancelF)
 	}
 
 	return outs
 }
 
+// finallyCancel runs the cancel handler of Finally through the interceptors
+// of ctx, they see the input as cancelled.
+func finallyCancel[Out, In any](ctx context.Context, input rop.Result[In],
+	cancelF func(ctx context.Context, r rop.Result[In]) Out) Out {
+
+	var out Out
+	rop.Intercept(ctx, "cancel", input, func(ctx context.Context) rop.Result[In] {
+		out = cancelF(ctx, input)
+		if input.IsSuccess() {
+			return rop.Carry(input, rop.CancelFromCtx[In](ctx, ctx.Err()))
+		}
+		return input
+	})
+	return out
+}
+
 func CheckCancelWith[In any](inputs <-chan rop.Result[In], outs chan rop.Result[bool],
 	cancelF func(r rop.Result[In]) error) <-chan rop.Result[bool] {
 
@@ -0,0 +1,137 @@
+package mass
+
+import (
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/group"
+	"time"
+)
+
+type cancelMode int
+
+const (
+	cancelAll cancelMode = iota
+	dropRemaining
+	stopImmediately
+	cancelWithin
+)
+
+// CancelPolicy decides what a stage does with the items it still receives
+// after the context is done.
+type CancelPolicy struct {
+	mode   cancelMode
+	within time.Duration
+}
+
+var (
+	// CancelAll reports every remaining item through cancelF (default).
+	CancelAll = CancelPolicy{mode: cancelAll}
+	// DropRemaining consumes the remaining items without reporting them.
+	DropRemaining = CancelPolicy{mode: dropRemaining}
+	// StopImmediately stops reading inputs and closes the output at once.
+	StopImmediately = CancelPolicy{mode: stopImmediately}
+)
+
+// CancelWithin reports remaining items through cancelF for at most d after
+// the stage observes the cancellation and drops the rest.
+func CancelWithin(d time.Duration) CancelPolicy {
+	return CancelPolicy{mode: cancelWithin, within: d}
+}
+
+type Option func(o *options)
+
+type options struct {
+	cancelPolicy  CancelPolicy
+	drainTimeout  time.Duration
+	idleTimeout   time.Duration
+	limiter       *rop.Limiter
+	recoverPanics bool
+	stage         string
+	dropFiltered  bool
+	parallel      *group.Parallel
+	queueDepth    func(delta int)
+}
+
+func WithCancelPolicy(policy CancelPolicy) Option {
+	return func(o *options) {
+		o.cancelPolicy = policy
+	}
+}
+
+// WithDrainTimeout makes the stage give up on a consumer that does not take
+// the next result within d once the context is done, instead of DrainTimeout.
+// A negative d waits for the consumer for as long as it takes, so that under
+// CancelAll every item is reported to a slow consumer; the stage then only
+// exits once its output is read.
+func WithDrainTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.drainTimeout = d
+	}
+}
+
+// WithIdleTimeout makes the stage give up on a producer that sends nothing
+// for d once the context is done, instead of IdleTimeout. A negative d waits
+// for the input to be closed, e.g. for a stage in front whose cancelF takes
+// a while to report its items.
+func WithIdleTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.idleTimeout = d
+	}
+}
+
+// WithRateLimit makes the stage take a token from limiter before it processes
+// a successful item. Share one limiter between stages or bridge lanes to put
+// them under a common limit.
+func WithRateLimit(limiter *rop.Limiter) Option {
+	return func(o *options) {
+		o.limiter = limiter
+	}
+}
+
+// WithoutPanicRecovery lets a panic in a stage function crash the process
+// instead of turning the item into rop.Fail with a *rop.PanicError.
+func WithoutPanicRecovery() Option {
+	return func(o *options) {
+		o.recoverPanics = false
+	}
+}
+
+// Named gives the stage a name for the results it produces, see rop.WithStage.
+func Named(name string) Option {
+	return func(o *options) {
+		o.stage = name
+	}
+}
+
+// WithQueueDepth makes the stage report how the number of items waiting in
+// front of it changes: the stage takes its inputs over one ahead, so an item
+// the stage in front has handed over counts until the stage gets to it, as
+// do the items buffered in its input channel. report gets the difference to
+// the depth it reported last, and the depth is taken back to 0 when the
+// stage ends. Bridge lanes sharing the option thus add up to the depth of
+// the whole stage.
+func WithQueueDepth(report func(delta int)) Option {
+	return func(o *options) {
+		o.queueDepth = report
+	}
+}
+
+// InParallel makes the group stages evaluate the members of the group
+// concurrently for each item, see group.Parallel.
+func InParallel(p group.Parallel) Option {
+	return func(o *options) {
+		o.parallel = &p
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		cancelPolicy:  CancelAll,
+		drainTimeout:  DrainTimeout,
+		idleTimeout:   IdleTimeout,
+		recoverPanics: true,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,20 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+)
+
+// RateLimit passes successful results on at the pace allowed by limiter.
+// Items still waiting for a token when ctx is done are cancelled via cancelF.
+func RateLimit[T any](ctx context.Context, inputs <-chan rop.Result[T], limiter *rop.Limiter,
+	cancelF func(ctx context.Context, r T) error, opts ...Option) <-chan rop.Result[T] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return in
+		},
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return CancelIfPossibleWithCtx[T](ctx, in, cancelF)
+		}, failOnPanic, newOptions(append(opts, WithRateLimit(limiter))))
+}
@@ -0,0 +1,77 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+)
+
+func Recover[T any](ctx context.Context, inputs <-chan rop.Result[T],
+	recoverF func(ctx context.Context, err error) T, matchF func(err error) bool,
+	cancelF func(ctx context.Context, r T) error,
+	opts ...Option) <-chan rop.Result[T] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return solo.RecoverWithCtx(ctx, in, recoverF, matchF)
+		},
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return CancelIfPossibleWithCtx[T](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
+}
+
+func RecoverWith[T any](ctx context.Context, inputs <-chan rop.Result[T],
+	altF func(ctx context.Context, err error) rop.Result[T], matchF func(err error) bool,
+	cancelF func(ctx context.Context, r T) error,
+	opts ...Option) <-chan rop.Result[T] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return solo.RecoverWithWithCtx(ctx, in, altF, matchF)
+		},
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return CancelIfPossibleWithCtx[T](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
+}
+
+func OrElse[T any](ctx context.Context, inputs <-chan rop.Result[T],
+	fallback T, matchF func(err error) bool,
+	cancelF func(ctx context.Context, r T) error,
+	opts ...Option) <-chan rop.Result[T] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return solo.OrElse(in, fallback, matchF)
+		},
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return CancelIfPossibleWithCtx[T](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
+}
+
+func FailToCancel[T any](ctx context.Context, inputs <-chan rop.Result[T],
+	matchF func(err error) bool,
+	cancelF func(ctx context.Context, r T) error,
+	opts ...Option) <-chan rop.Result[T] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return solo.FailToCancel(in, matchF)
+		},
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return CancelIfPossibleWithCtx[T](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
+}
+
+func CancelToFail[T any](ctx context.Context, inputs <-chan rop.Result[T],
+	matchF func(err error) bool,
+	cancelF func(ctx context.Context, r T) error,
+	opts ...Option) <-chan rop.Result[T] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return solo.CancelToFail(in, matchF)
+		},
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return CancelIfPossibleWithCtx[T](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
+}
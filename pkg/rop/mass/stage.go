@@ -0,0 +1,257 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"time"
+)
+
+// DrainTimeout bounds how long a stage waits for its consumer once the context
+// is done. A consumer that does not take the next result within this period is
+// treated as gone and the stage exits without reporting the remaining items,
+// unless WithDrainTimeout says otherwise.
+const DrainTimeout = 500 * time.Millisecond
+
+// IdleTimeout is how long a stage waits for its next input once the context
+// is done, unless WithIdleTimeout says otherwise.
+const IdleTimeout = 500 * time.Millisecond
+
+func run[In, Out any](ctx context.Context, inputs <-chan In,
+	processF func(ctx context.Context, in In) Out,
+	cancelF func(ctx context.Context, in In) Out,
+	panicF func(ctx context.Context, in In, err error) Out, o options) <-chan Out {
+
+	out := make(chan Out)
+	ctx = stageCtx(ctx, o)
+
+	if o.queueDepth != nil {
+		inputs = intake(ctx, inputs, o)
+		o.idleTimeout = -1 // the intake gives up on idle inputs and closes
+	}
+
+	go func(ctx context.Context, inputs <-chan In) {
+		defer discard(inputs, o) // lets the stages in front finish
+		defer close(out)
+
+		var cancelledAt time.Time
+		for {
+			in, ok := next(ctx, inputs, o)
+			if !ok {
+				return
+			}
+
+			if o.dropFiltered && isFiltered(in) {
+				continue
+			}
+
+			if o.limiter != nil && ctx.Err() == nil && isSuccess(in) {
+				_ = o.limiter.Wait(ctx) // fails only when ctx is done
+			}
+
+			var res Out
+			if ctx.Err() != nil {
+				if cancelledAt.IsZero() {
+					cancelledAt = time.Now()
+				}
+
+				if !o.cancelPolicy.reports(cancelledAt) {
+					continue
+				}
+				res = call(ctx, in, cancelF, panicF, o) // cancel current !!!
+			} else {
+				res = call(ctx, in, processF, panicF, o)
+			}
+
+			if !emit(ctx, out, res, o) {
+				return
+			}
+		}
+	}(ctx, inputs)
+
+	return out
+}
+
+// intake takes the inputs over for a stage reporting its queue depth, see
+// WithQueueDepth. The depth is the input it holds for the stage, if any, and
+// the ones buffered in inputs as seen when it takes one.
+func intake[T any](ctx context.Context, inputs <-chan T, o options) <-chan T {
+
+	waiting := make(chan T)
+
+	go func() {
+		defer discard(inputs, o)
+		defer close(waiting)
+
+		depth := 0
+		report := func(now int) {
+			if now != depth {
+				o.queueDepth(now - depth)
+				depth = now
+			}
+		}
+		defer report(0)
+
+		for {
+			in, ok := next(ctx, inputs, o)
+			if !ok {
+				return
+			}
+
+			report(1 + len(inputs))
+			waiting <- in // the stage reads until it is closed
+			report(len(inputs))
+		}
+	}()
+
+	return waiting
+}
+
+// stageCtx names the stage in ctx, see Named.
+func stageCtx(ctx context.Context, o options) context.Context {
+	if o.stage != "" {
+		return rop.WithStage(ctx, o.stage)
+	}
+	return ctx
+}
+
+// call keeps the stage goroutine alive when f panics: the item is handed to
+// panicF with a *rop.PanicError and the stage goes on with the next one.
+func call[In, Out any](ctx context.Context, in In,
+	f func(ctx context.Context, in In) Out,
+	panicF func(ctx context.Context, in In, err error) Out, o options) (res Out) {
+
+	if !o.recoverPanics {
+		return f(ctx, in)
+	}
+
+	defer func() {
+		if v := recover(); v != nil {
+			res = panicF(ctx, in, rop.NewPanicError(v))
+		}
+	}()
+
+	return f(ctx, in)
+}
+
+func failOnPanic[In, T any](_ context.Context, _ In, err error) rop.Result[T] {
+	return rop.Fail[T](err)
+}
+
+func isSuccess(in any) bool {
+	if r, ok := in.(interface{ IsSuccess() bool }); ok {
+		return r.IsSuccess()
+	}
+	return true
+}
+
+func isFiltered(in any) bool {
+	if r, ok := in.(interface{ IsFiltered() bool }); ok {
+		return r.IsFiltered()
+	}
+	return false
+}
+
+// next receives the next input. Once ctx is done it gives up on a producer
+// that sends nothing for the idle timeout, and at once under StopImmediately.
+func next[T any](ctx context.Context, inputs <-chan T, o options) (T, bool) {
+
+	var zero T
+
+	select {
+	case in, ok := <-inputs:
+		if ok && (ctx.Err() == nil || o.cancelPolicy.mode != stopImmediately) {
+			return in, true
+		}
+		return zero, false
+	case <-ctx.Done():
+	}
+
+	if o.cancelPolicy.mode == stopImmediately {
+		return zero, false
+	}
+	return receiveWithin(inputs, o.idleTimeout)
+}
+
+func (p CancelPolicy) reports(cancelledAt time.Time) bool {
+	switch p.mode {
+	case dropRemaining, stopImmediately:
+		return false
+	case cancelWithin:
+		return time.Since(cancelledAt) < p.within
+	default:
+		return true
+	}
+}
+
+// emit sends value to the consumer. Once ctx is done it gives up on a
+// consumer that does not take value within the drain timeout, and at once
+// under StopImmediately.
+func emit[T any](ctx context.Context, out chan<- T, value T, o options) bool {
+
+	select {
+	case out <- value:
+		return true
+	case <-ctx.Done():
+	}
+
+	if o.cancelPolicy.mode == stopImmediately {
+		return false
+	}
+	return sendWithin(out, value, o.drainTimeout)
+}
+
+// Send delivers value to out the way a stage built with opts emits its
+// results, see WithDrainTimeout. It reports whether value was taken.
+func Send[T any](ctx context.Context, out chan<- T, value T, opts ...Option) bool {
+	return emit(ctx, out, value, newOptions(opts))
+}
+
+// discard drains inputs until they are closed or idle for o.idleTimeout.
+func discard[T any](inputs <-chan T, o options) {
+	for {
+		if _, ok := receiveWithin(inputs, o.idleTimeout); !ok {
+			return
+		}
+	}
+}
+
+// receiveWithin waits up to timeout for the next value, a negative timeout
+// waits for as long as it takes.
+func receiveWithin[T any](inputs <-chan T, timeout time.Duration) (T, bool) {
+
+	if timeout < 0 {
+		in, ok := <-inputs
+		return in, ok
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case in, ok := <-inputs:
+		return in, ok
+	case <-timer.C:
+		var zero T
+		return zero, false
+	}
+}
+
+// sendWithin waits up to timeout for the consumer to take value, a negative
+// timeout waits for as long as it takes.
+func sendWithin[T any](out chan<- T, value T, timeout time.Duration) bool {
+
+	if timeout < 0 {
+		out <- value
+		return true
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case out <- value:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
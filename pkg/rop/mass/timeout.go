@@ -0,0 +1,25 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"time"
+)
+
+// Timeout runs switchF for every successful item under its own deadline, see
+// solo.TimeoutWithCtx.
+func Timeout[In any, Out any](ctx context.Context, inputs <-chan rop.Result[In],
+	timeout time.Duration, policy solo.TimeoutPolicy,
+	switchF func(ctx context.Context, r In) rop.Result[Out],
+	cancelF func(ctx context.Context, r In) error,
+	opts ...Option) <-chan rop.Result[Out] {
+
+	return run(ctx, inputs,
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return solo.TimeoutWithCtx(ctx, in, timeout, policy, switchF)
+		},
+		func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			return solo.CancelWithCtx[In, Out](ctx, in, cancelF)
+		}, failOnPanic, newOptions(opts))
+}
@@ -0,0 +1,144 @@
+package rop
+
+import (
+	"context"
+)
+
+const ResultOfKey = "result-of"
+
+// Key is a typed metadata key. Keys are compared by name and value type, so
+// declare each one once, e.g. var TenantKey = rop.NewKey[string]("tenant").
+type Key[V any] struct {
+	name string
+}
+
+func NewKey[V any](name string) Key[V] {
+	return Key[V]{name: name}
+}
+
+func (k Key[V]) String() string {
+	return k.name
+}
+
+// meta is an immutable list of metadata entries, newest first. Results share
+// the entries they have in common, so setting a value never touches the
+// results it was derived from.
+type meta struct {
+	key    any
+	value  any
+	parent *meta
+}
+
+func (m *meta) lookup(key any) (any, bool) {
+	for ; m != nil; m = m.parent {
+		if m.key == key {
+			return m.value, true
+		}
+	}
+	return nil, false
+}
+
+// SetMeta returns r with value stored under key.
+func SetMeta[T, V any](r Result[T], key Key[V], value V) Result[T] {
+	r.meta = &meta{key: key, value: value, parent: r.meta}
+	return r
+}
+
+func GetMeta[T, V any](r Result[T], key Key[V]) (V, bool) {
+	return asMeta[V](r.meta.lookup(key))
+}
+
+// SetOutcomeMeta is SetMeta for code that only knows the Outcome, such as an
+// Interceptor. Outcomes that are not a Result are returned as they are.
+func SetOutcomeMeta[V any](o Outcome, key Key[V], value V) Outcome {
+	if r, ok := o.(interface{ withMeta(key, value any) Outcome }); ok {
+		return r.withMeta(key, value)
+	}
+	return o
+}
+
+func GetOutcomeMeta[V any](o Outcome, key Key[V]) (V, bool) {
+	if r, ok := o.(interface{ lookupMeta(key any) (any, bool) }); ok {
+		return asMeta[V](r.lookupMeta(key))
+	}
+	var zero V
+	return zero, false
+}
+
+func (r Result[T]) withMeta(key, value any) Outcome {
+	r.meta = &meta{key: key, value: value, parent: r.meta}
+	return r
+}
+
+func (r Result[T]) lookupMeta(key any) (any, bool) {
+	return r.meta.lookup(key)
+}
+
+// MetaKeys lists the names of the keys set on r, newest first.
+func MetaKeys[T any](r Result[T]) []string {
+	names := make([]string, 0)
+	r.EachMeta(func(name string, _ any) {
+		names = append(names, name)
+	})
+	return names
+}
+
+// EachMeta calls f with the name and the value of every key set on r, newest first.
+func (r Result[T]) EachMeta(f func(name string, value any)) {
+	seen := make(map[any]bool)
+	for m := r.meta; m != nil; m = m.parent {
+		if !seen[m.key] {
+			seen[m.key] = true
+			f(m.key.(interface{ String() string }).String(), m.value)
+		}
+	}
+}
+
+// WithResultOf exposes the trail, the metadata and the cancel reason of r to the functions
+// called with the returned context, see GetTrailFromCtx and GetMetaFromCtx.
+func WithResultOf[T any](ctx context.Context, r Result[T]) context.Context {
+	if len(r.trail) == 0 && r.meta == nil && r.reason == CancelUnspecified {
+		return ctx
+	}
+	return context.WithValue(ctx, ResultOfKey, origin{trail: r.trail, meta: r.meta, reason: r.reason})
+}
+
+func GetMetaFromCtx[V any](ctx context.Context, key Key[V]) (V, bool) {
+	o, _ := ctx.Value(ResultOfKey).(origin)
+	return asMeta[V](o.meta.lookup(key))
+}
+
+type origin struct {
+	trail  []string
+	meta   *meta
+	reason CancelReason
+}
+
+func asMeta[V any](value any, ok bool) (V, bool) {
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return value.(V), true
+}
+
+// mergeMeta puts the entries of to on top of the ones of from.
+func mergeMeta(from, to *meta) *meta {
+	if from == nil {
+		return to
+	}
+
+	entries := make([]*meta, 0)
+	for m := to; m != nil; m = m.parent {
+		if m == from { // to was derived from from
+			return to
+		}
+		entries = append(entries, m)
+	}
+
+	merged := from
+	for i := len(entries) - 1; i >= 0; i-- {
+		merged = &meta{key: entries[i].key, value: entries[i].value, parent: merged}
+	}
+	return merged
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"time"
+)
+
+const (
+	Success  = "success"
+	Fail     = "fail"
+	Cancel   = "cancel"
+	Filtered = "filtered"
+)
+
+// Recorder is a metrics backend the stages report into.
+type Recorder interface {
+	// Observe records a call of a user function by a stage of the given kind,
+	// the state of the result it produced and how long it took.
+	Observe(stage, kind, state string, took time.Duration)
+	// AddQueueDepth changes the number of items waiting in front of the stage.
+	AddQueueDepth(stage string, delta int)
+}
+
+// Interceptor reports every user function call of the stages into rec. The
+// items a stage cancels through its cancelF are counted under the kind
+// "cancel".
+func Interceptor(rec Recorder) rop.Interceptor {
+	return func(ctx context.Context, call rop.Call,
+		next func(ctx context.Context) rop.Outcome) rop.Outcome {
+
+		started := time.Now()
+		out := next(ctx)
+		rec.Observe(call.Stage, call.Kind, StateOf(out), time.Since(started))
+		return out
+	}
+}
+
+// QueueDepth is a mass option reporting the queue depth of the stage into
+// rec, see mass.WithQueueDepth. Pass it to a bridge stage to get the depth
+// summed over its lanes.
+func QueueDepth(rec Recorder, stage string) mass.Option {
+	return mass.WithQueueDepth(func(delta int) {
+		rec.AddQueueDepth(stage, delta)
+	})
+}
+
+// StateOf names the track of o, a nil outcome counts as failed.
+func StateOf(o rop.Outcome) string {
+	switch {
+	case o == nil:
+		return Fail
+	case o.IsSuccess():
+		return Success
+	case o.IsFiltered():
+		return Filtered
+	case o.IsCancel():
+		return Cancel
+	default:
+		return Fail
+	}
+}
+
+// Multi reports into all recs.
+func Multi(recs ...Recorder) Recorder {
+	return multi(recs)
+}
+
+type multi []Recorder
+
+func (m multi) Observe(stage, kind, state string, took time.Duration) {
+	for _, rec := range m {
+		rec.Observe(stage, kind, state, took)
+	}
+}
+
+func (m multi) AddQueueDepth(stage string, delta int) {
+	for _, rec := range m {
+		rec.AddQueueDepth(stage, delta)
+	}
+}
@@ -0,0 +1,196 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds in seconds of the latency histogram.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Registry is an in-process Recorder. It serves its metrics in the
+// Prometheus text exposition format:
+//
+//	rop_stage_results_total{stage,kind,state}    counter
+//	rop_stage_duration_seconds{stage,kind}       histogram
+//	rop_stage_queue_depth{stage}                 gauge
+type Registry struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	results   map[resultKey]int64
+	durations map[callKey]*histogram
+	depths    map[string]int64
+}
+
+type callKey struct {
+	stage, kind string
+}
+
+type resultKey struct {
+	callKey
+	state string
+}
+
+type histogram struct {
+	counts []int64 // per bucket, not cumulative
+	count  int64
+	sum    float64
+}
+
+// NewRegistry builds a Registry with the given histogram buckets in seconds,
+// DefaultBuckets if none are given.
+func NewRegistry(buckets ...float64) *Registry {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &Registry{
+		buckets:   buckets,
+		results:   make(map[resultKey]int64),
+		durations: make(map[callKey]*histogram),
+		depths:    make(map[string]int64),
+	}
+}
+
+func (r *Registry) Observe(stage, kind, state string, took time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := callKey{stage: stage, kind: kind}
+	r.results[resultKey{callKey: key, state: state}]++
+
+	h, ok := r.durations[key]
+	if !ok {
+		h = &histogram{counts: make([]int64, len(r.buckets))}
+		r.durations[key] = h
+	}
+
+	seconds := took.Seconds()
+	if i := sort.SearchFloat64s(r.buckets, seconds); i < len(r.buckets) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += seconds
+}
+
+func (r *Registry) AddQueueDepth(stage string, delta int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.depths[stage] += int64(delta)
+}
+
+// Count returns how many results of the given state the stage produced.
+func (r *Registry) Count(stage, kind, state string) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.results[resultKey{callKey: callKey{stage: stage, kind: kind}, state: state}]
+}
+
+// QueueDepth returns the number of items waiting in front of the stage.
+func (r *Registry) QueueDepth(stage string) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.depths[stage]
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = r.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+
+	b.WriteString("# HELP rop_stage_results_total Results produced by stage user functions.\n")
+	b.WriteString("# TYPE rop_stage_results_total counter\n")
+	results := make([]resultKey, 0, len(r.results))
+	for key := range r.results {
+		results = append(results, key)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].less(results[j])
+	})
+	for _, key := range results {
+		fmt.Fprintf(&b, "rop_stage_results_total{stage=%s,kind=%s,state=%s} %d\n",
+			quote(key.stage), quote(key.kind), quote(key.state), r.results[key])
+	}
+
+	b.WriteString("# HELP rop_stage_duration_seconds Duration of stage user function calls.\n")
+	b.WriteString("# TYPE rop_stage_duration_seconds histogram\n")
+	calls := make([]callKey, 0, len(r.durations))
+	for key := range r.durations {
+		calls = append(calls, key)
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		return calls[i].less(calls[j])
+	})
+	for _, key := range calls {
+		h := r.durations[key]
+		labels := fmt.Sprintf("stage=%s,kind=%s", quote(key.stage), quote(key.kind))
+
+		var cumulative int64
+		for i, le := range r.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "rop_stage_duration_seconds_bucket{%s,le=%s} %d\n",
+				labels, quote(formatFloat(le)), cumulative)
+		}
+		fmt.Fprintf(&b, "rop_stage_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, h.count)
+		fmt.Fprintf(&b, "rop_stage_duration_seconds_sum{%s} %s\n", labels, formatFloat(h.sum))
+		fmt.Fprintf(&b, "rop_stage_duration_seconds_count{%s} %d\n", labels, h.count)
+	}
+
+	b.WriteString("# HELP rop_stage_queue_depth Items waiting in front of a stage.\n")
+	b.WriteString("# TYPE rop_stage_queue_depth gauge\n")
+	stages := make([]string, 0, len(r.depths))
+	for stage := range r.depths {
+		stages = append(stages, stage)
+	}
+	sort.Strings(stages)
+	for _, stage := range stages {
+		fmt.Fprintf(&b, "rop_stage_queue_depth{stage=%s} %d\n", quote(stage), r.depths[stage])
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (k callKey) less(o callKey) bool {
+	if k.stage != o.stage {
+		return k.stage < o.stage
+	}
+	return k.kind < o.kind
+}
+
+func (k resultKey) less(o resultKey) bool {
+	if k.callKey != o.callKey {
+		return k.callKey.less(o.callKey)
+	}
+	return k.state < o.state
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quote(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,33 @@
+package rop
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the error of a Result produced from a recovered panic.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// NewPanicError captures the stack of the panicking goroutine, so call it from
+// the deferred function that recovered value.
+func NewPanicError(value any) *PanicError {
+	return &PanicError{
+		Value: value,
+		Stack: debug.Stack(),
+	}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap exposes the panic value when it is an error itself.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
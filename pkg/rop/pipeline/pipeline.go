@@ -0,0 +1,247 @@
+package pipeline
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/bridge"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"time"
+)
+
+// Pipeline is a chain of stages from In to Out defined once and runnable on
+// a single value (Run), a stream (RunStream) or bridge lanes (RunLanes).
+// Every stage carries its name and cancel function: the name is given to the
+// results like rop.WithStage does, cancelF reports the items that reach the
+// stage after the context is done.
+type Pipeline[In, Out any] struct {
+	solo         func(ctx context.Context, in rop.Result[In]) rop.Result[Out]
+	stream       func(ctx context.Context, inputs <-chan rop.Result[In]) <-chan rop.Result[Out]
+	interceptors []rop.Interceptor
+}
+
+// New starts an empty pipeline for inputs of type In.
+func New[In any]() Pipeline[In, In] {
+	return Pipeline[In, In]{
+		solo: func(_ context.Context, in rop.Result[In]) rop.Result[In] {
+			return in
+		},
+		stream: func(_ context.Context, inputs <-chan rop.Result[In]) <-chan rop.Result[In] {
+			return inputs
+		},
+	}
+}
+
+// Intercept registers interceptors for every stage of the pipeline, the ones
+// appended later included, in whatever mode it runs.
+func (p Pipeline[In, Out]) Intercept(interceptors ...rop.Interceptor) Pipeline[In, Out] {
+	p.interceptors = append(p.interceptors[:len(p.interceptors):len(p.interceptors)], interceptors...)
+	return p
+}
+
+// Run runs the pipeline on a single value.
+func (p Pipeline[In, Out]) Run(ctx context.Context, input In) rop.Result[Out] {
+	return p.solo(p.withInterceptors(ctx), rop.Success(input))
+}
+
+// RunResult runs the pipeline on a result of an earlier step.
+func (p Pipeline[In, Out]) RunResult(ctx context.Context, input rop.Result[In]) rop.Result[Out] {
+	return p.solo(p.withInterceptors(ctx), input)
+}
+
+// RunStream runs the pipeline as a chain of mass stages.
+func (p Pipeline[In, Out]) RunStream(ctx context.Context, inputs <-chan In) <-chan rop.Result[Out] {
+	return p.stream(p.withInterceptors(ctx), mass.Successes(ctx, inputs))
+}
+
+// RunResults runs the pipeline as a chain of mass stages on results.
+func (p Pipeline[In, Out]) RunResults(ctx context.Context,
+	inputs <-chan rop.Result[In]) <-chan rop.Result[Out] {
+
+	return p.stream(p.withInterceptors(ctx), inputs)
+}
+
+// RunLanes runs the pipeline on every bridge lane.
+func (p Pipeline[In, Out]) RunLanes(ctx context.Context, inputChs chan chan In) chan chan rop.Result[Out] {
+	return bridge.Lanes(ctx, inputChs, p.RunStream)
+}
+
+func Validate[In, T any](p Pipeline[In, T], name string,
+	validateF func(ctx context.Context, in T) bool, errMsg string,
+	cancelF func(ctx context.Context, r T) error, opts ...mass.Option) Pipeline[In, T] {
+
+	return then(p, name, cancelF,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return solo.AndValidateWithCtx(ctx, in, validateF, errMsg)
+		},
+		func(ctx context.Context, inputs <-chan rop.Result[T], opts ...mass.Option) <-chan rop.Result[T] {
+			return mass.AndValidate(ctx, inputs, validateF, cancelF, errMsg, opts...)
+		}, opts)
+}
+
+func Switch[In, Mid, Out any](p Pipeline[In, Mid], name string,
+	switchF func(ctx context.Context, r Mid) rop.Result[Out],
+	cancelF func(ctx context.Context, r Mid) error, opts ...mass.Option) Pipeline[In, Out] {
+
+	return then(p, name, cancelF,
+		func(ctx context.Context, in rop.Result[Mid]) rop.Result[Out] {
+			return solo.SwitchWithCtx(ctx, in, switchF)
+		},
+		func(ctx context.Context, inputs <-chan rop.Result[Mid], opts ...mass.Option) <-chan rop.Result[Out] {
+			return mass.Switch(ctx, inputs, switchF, cancelF, opts...)
+		}, opts)
+}
+
+func Map[In, Mid, Out any](p Pipeline[In, Mid], name string,
+	mapF func(ctx context.Context, r Mid) Out,
+	cancelF func(ctx context.Context, r Mid) error, opts ...mass.Option) Pipeline[In, Out] {
+
+	return then(p, name, cancelF,
+		func(ctx context.Context, in rop.Result[Mid]) rop.Result[Out] {
+			return solo.MapWithCtx(ctx, in, mapF)
+		},
+		func(ctx context.Context, inputs <-chan rop.Result[Mid], opts ...mass.Option) <-chan rop.Result[Out] {
+			return mass.Map(ctx, inputs, mapF, cancelF, opts...)
+		}, opts)
+}
+
+func Try[In, Mid, Out any](p Pipeline[In, Mid], name string,
+	withErrF func(ctx context.Context, r Mid) (Out, error),
+	cancelF func(ctx context.Context, r Mid) error, opts ...mass.Option) Pipeline[In, Out] {
+
+	return then(p, name, cancelF,
+		func(ctx context.Context, in rop.Result[Mid]) rop.Result[Out] {
+			return solo.TryWithCtx(ctx, in, withErrF)
+		},
+		func(ctx context.Context, inputs <-chan rop.Result[Mid], opts ...mass.Option) <-chan rop.Result[Out] {
+			return mass.Try(ctx, inputs, withErrF, cancelF, opts...)
+		}, opts)
+}
+
+func Tee[In, T any](p Pipeline[In, T], name string,
+	deadEndF func(ctx context.Context, r rop.Result[T]),
+	cancelF func(ctx context.Context, r T) error, opts ...mass.Option) Pipeline[In, T] {
+
+	return then(p, name, cancelF,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return solo.TeeWithCtx(ctx, in, deadEndF)
+		},
+		func(ctx context.Context, inputs <-chan rop.Result[T], opts ...mass.Option) <-chan rop.Result[T] {
+			return mass.Tee(ctx, inputs, deadEndF, cancelF, opts...)
+		}, opts)
+}
+
+func Filter[In, T any](p Pipeline[In, T], name string,
+	keepF func(ctx context.Context, r T) bool,
+	cancelF func(ctx context.Context, r T) error, opts ...mass.Option) Pipeline[In, T] {
+
+	return then(p, name, cancelF,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return solo.FilterWithCtx(ctx, in, keepF)
+		},
+		func(ctx context.Context, inputs <-chan rop.Result[T], opts ...mass.Option) <-chan rop.Result[T] {
+			return mass.Filter(ctx, inputs, keepF, cancelF, opts...)
+		}, opts)
+}
+
+func Recover[In, T any](p Pipeline[In, T], name string,
+	recoverF func(ctx context.Context, err error) T, matchF func(err error) bool,
+	cancelF func(ctx context.Context, r T) error, opts ...mass.Option) Pipeline[In, T] {
+
+	return then(p, name, cancelF,
+		func(ctx context.Context, in rop.Result[T]) rop.Result[T] {
+			return solo.RecoverWithCtx(ctx, in, recoverF, matchF)
+		},
+		func(ctx context.Context, inputs <-chan rop.Result[T], opts ...mass.Option) <-chan rop.Result[T] {
+			return mass.Recover(ctx, inputs, recoverF, matchF, cancelF, opts...)
+		}, opts)
+}
+
+func Timeout[In, Mid, Out any](p Pipeline[In, Mid], name string,
+	timeout time.Duration, policy solo.TimeoutPolicy,
+	switchF func(ctx context.Context, r Mid) rop.Result[Out],
+	cancelF func(ctx context.Context, r Mid) error, opts ...mass.Option) Pipeline[In, Out] {
+
+	return then(p, name, cancelF,
+		func(ctx context.Context, in rop.Result[Mid]) rop.Result[Out] {
+			return solo.TimeoutWithCtx(ctx, in, timeout, policy, switchF)
+		},
+		func(ctx context.Context, inputs <-chan rop.Result[Mid], opts ...mass.Option) <-chan rop.Result[Out] {
+			return mass.Timeout(ctx, inputs, timeout, policy, switchF, cancelF, opts...)
+		}, opts)
+}
+
+// Lift appends the custom stage op, see rop.Operator.
+func Lift[In, Mid, Out any](p Pipeline[In, Mid], name string,
+	op rop.Operator[Mid, Out], opts ...mass.Option) Pipeline[In, Out] {
+
+	intercepted := rop.NewOperator(
+		func(ctx context.Context, in rop.Result[Mid]) rop.Result[Out] {
+			return solo.Lift(ctx, in, op)
+		},
+		func(ctx context.Context, in rop.Result[Mid]) rop.Result[Out] {
+			return rop.Intercept(ctx, "cancel", in, func(ctx context.Context) rop.Result[Out] {
+				return op.Cancel(ctx, in)
+			})
+		})
+
+	return thenOp(p, name, intercepted,
+		func(ctx context.Context, inputs <-chan rop.Result[Mid], opts ...mass.Option) <-chan rop.Result[Out] {
+			return mass.Lift(ctx, inputs, op, opts...)
+		}, opts)
+}
+
+// then appends a stage given by its single value and its stream form.
+func then[In, Mid, Out any](p Pipeline[In, Mid], name string,
+	cancelF func(ctx context.Context, r Mid) error,
+	soloF func(ctx context.Context, in rop.Result[Mid]) rop.Result[Out],
+	massF func(ctx context.Context, inputs <-chan rop.Result[Mid], opts ...mass.Option) <-chan rop.Result[Out],
+	opts []mass.Option) Pipeline[In, Out] {
+
+	return thenOp(p, name,
+		rop.NewOperator(soloF,
+			func(ctx context.Context, in rop.Result[Mid]) rop.Result[Out] {
+				return solo.CancelWithCtx[Mid, Out](ctx, in, cancelF)
+			}), massF, opts)
+}
+
+// thenOp appends a stage running op on single values and massF on streams.
+// In the single value form a panic turns the result into rop.Fail like a
+// mass stage with panic recovery does.
+func thenOp[In, Mid, Out any](p Pipeline[In, Mid], name string, op rop.Operator[Mid, Out],
+	massF func(ctx context.Context, inputs <-chan rop.Result[Mid], opts ...mass.Option) <-chan rop.Result[Out],
+	opts []mass.Option) Pipeline[In, Out] {
+
+	if name != "" {
+		opts = append(opts[:len(opts):len(opts)], mass.Named(name))
+	}
+
+	return Pipeline[In, Out]{
+		interceptors: p.interceptors,
+		solo: func(ctx context.Context, in rop.Result[In]) rop.Result[Out] {
+			mid := p.solo(ctx, in)
+
+			if name != "" {
+				ctx = rop.WithStage(ctx, name)
+			}
+
+			if ctx.Err() != nil {
+				return op.Cancel(ctx, mid)
+			}
+
+			return solo.Protect(func() rop.Result[Out] {
+				return op.Apply(ctx, mid)
+			})
+		},
+		stream: func(ctx context.Context, inputs <-chan rop.Result[In]) <-chan rop.Result[Out] {
+			return massF(ctx, p.stream(ctx, inputs), opts...)
+		},
+	}
+}
+
+func (p Pipeline[In, Out]) withInterceptors(ctx context.Context) context.Context {
+	if len(p.interceptors) == 0 {
+		return ctx
+	}
+	return rop.WithInterceptors(ctx, p.interceptors...)
+}
@@ -1,10 +1,15 @@
 package rop
 
 type Result[T any] struct {
-	result    T
-	err       error
-	isSuccess bool
-	isCancel  bool
+	result     T
+	err        error
+	isSuccess  bool
+	isCancel   bool
+	isFiltered bool
+	trail      []string
+	meta       *meta
+	reason     CancelReason
+	cause      error
 }
 
 func Success[T any](r T) Result[T] {
@@ -32,6 +37,15 @@ func Cancel[T any](err error) Result[T] {
 	}
 }
 
+// Filtered takes the input off every track: stages pass it through untouched
+// and Finally handlers never see it. reason is returned by Err.
+func Filtered[T any](reason error) Result[T] {
+	return Result[T]{
+		err:        reason,
+		isFiltered: true,
+	}
+}
+
 func (r Result[T]) Result() T {
 	return r.result
 }
@@ -47,3 +61,7 @@ func (r Result[T]) IsSuccess() bool {
 func (r Result[T]) IsCancel() bool {
 	return r.isCancel
 }
+
+func (r Result[T]) IsFiltered() bool {
+	return r.isFiltered
+}
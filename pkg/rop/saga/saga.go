@@ -0,0 +1,134 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"sync"
+)
+
+// Saga collects the compensating actions of the steps that completed so far.
+// Thread one Saga through a solo chain with Step/SwitchStep and close it with
+// End, which undoes the completed steps in reverse order if the chain failed
+// or was cancelled.
+type Saga struct {
+	mu            sync.Mutex
+	compensations []func(ctx context.Context) error
+}
+
+// CompensationError reports a compensating action that failed. Step is the
+// 1-based position of the step being compensated.
+type CompensationError struct {
+	Step int
+	Err  error
+}
+
+func (e *CompensationError) Error() string {
+	return fmt.Sprintf("compensate step %d: %v", e.Step, e.Err)
+}
+
+func (e *CompensationError) Unwrap() error {
+	return e.Err
+}
+
+func New() *Saga {
+	return &Saga{}
+}
+
+// Step runs stepF as solo.TryWithCtx does. On success compensateF is
+// registered with the produced value.
+func Step[In any, Out any](ctx context.Context, s *Saga, input rop.Result[In],
+	stepF func(ctx context.Context, r In) (Out, error),
+	compensateF func(ctx context.Context, r Out) error) rop.Result[Out] {
+
+	out := solo.TryWithCtx(ctx, input, stepF)
+	register(s, out, compensateF)
+	return out
+}
+
+// SwitchStep runs switchF as solo.SwitchWithCtx does. On success compensateF
+// is registered with the produced value.
+func SwitchStep[In any, Out any](ctx context.Context, s *Saga, input rop.Result[In],
+	switchF func(ctx context.Context, r In) rop.Result[Out],
+	compensateF func(ctx context.Context, r Out) error) rop.Result[Out] {
+
+	out := solo.SwitchWithCtx(ctx, input, switchF)
+	register(s, out, compensateF)
+	return out
+}
+
+// End returns a successful result unchanged. Otherwise it runs the registered
+// compensations from the last completed step back to the first and joins their
+// errors into the error of the returned result, which keeps its track. The
+// compensations run even if ctx is already cancelled. A compensation that
+// panics is reported as a CompensationError around a *rop.PanicError and
+// the earlier steps are still compensated.
+func End[T any](ctx context.Context, s *Saga, result rop.Result[T]) rop.Result[T] {
+
+	compensations := s.take()
+	if result.IsSuccess() {
+		return result
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	errs := []error{result.Err()}
+	for i := len(compensations) - 1; i >= 0; i-- {
+		if compensations[i] == nil {
+			continue
+		}
+		if err := compensate(ctx, compensations[i]); err != nil {
+			errs = append(errs, &CompensationError{Step: i + 1, Err: err})
+		}
+	}
+
+	if len(errs) == 1 {
+		return result
+	}
+
+	if result.IsCancel() {
+		return rop.Carry(result, rop.Cancel[T](errors.Join(errs...)))
+	}
+	return rop.Carry(result, rop.Fail[T](errors.Join(errs...)))
+}
+
+func compensate(ctx context.Context, compensateF func(ctx context.Context) error) (err error) {
+
+	defer func() {
+		if v := recover(); v != nil {
+			err = rop.NewPanicError(v)
+		}
+	}()
+
+	return compensateF(ctx)
+}
+
+func register[Out any](s *Saga, out rop.Result[Out], compensateF func(ctx context.Context, r Out) error) {
+
+	if !out.IsSuccess() {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if compensateF == nil { // nothing to undo, but the step still counts
+		s.compensations = append(s.compensations, nil)
+		return
+	}
+
+	value := out.Result()
+	s.compensations = append(s.compensations, func(ctx context.Context) error {
+		return compensateF(ctx, value)
+	})
+}
+
+func (s *Saga) take() []func(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	compensations := s.compensations
+	s.compensations = nil
+	return compensations
+}
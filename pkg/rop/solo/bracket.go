@@ -0,0 +1,92 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+)
+
+// Bracket runs bodyF with the resource held by acquired and releases it
+// whatever bodyF returns, and also when it panics (the panic is re-raised
+// after the release). A release error turns a success into rop.Fail and is
+// joined to the error of a failed or cancelled body. Release runs even if
+// ctx is already cancelled. A non-successful acquired is passed on as is.
+func Bracket[R any, Out any](ctx context.Context, acquired rop.Result[R],
+	releaseF func(ctx context.Context, r R) error,
+	bodyF func(ctx context.Context, r R) rop.Result[Out]) rop.Result[Out] {
+
+	if !acquired.IsSuccess() {
+		if acquired.IsCancel() {
+			return rop.Carry(acquired, rop.Cancel[Out](acquired.Err()))
+		} else {
+			return rop.Carry(acquired, rop.Fail[Out](acquired.Err()))
+		}
+	}
+
+	return rop.Invoke(ctx, "bracket", acquired, func(ctx context.Context) rop.Result[Out] {
+		return bracket(rop.WithResultOf(ctx, acquired), acquired.Result(), releaseF, bodyF)
+	})
+}
+
+// Using acquires a resource for the input with acquireF and runs bodyF with
+// both of them like Bracket does.
+func Using[In any, R any, Out any](ctx context.Context, input rop.Result[In],
+	acquireF func(ctx context.Context, r In) (R, error),
+	releaseF func(ctx context.Context, r R) error,
+	bodyF func(ctx context.Context, in In, r R) rop.Result[Out]) rop.Result[Out] {
+
+	if !input.IsSuccess() {
+		if input.IsCancel() {
+			return rop.Carry(input, rop.Cancel[Out](input.Err()))
+		} else {
+			return rop.Carry(input, rop.Fail[Out](input.Err()))
+		}
+	}
+
+	return rop.Invoke(ctx, "using", input, func(ctx context.Context) rop.Result[Out] {
+		resource, err := acquireF(rop.WithResultOf(ctx, input), input.Result())
+		if err != nil {
+			return rop.Fail[Out](err)
+		}
+
+		return bracket(rop.WithResultOf(ctx, input), resource, releaseF,
+			func(ctx context.Context, r R) rop.Result[Out] {
+				return bodyF(ctx, input.Result(), r)
+			})
+	})
+}
+
+func bracket[R any, Out any](ctx context.Context, resource R,
+	releaseF func(ctx context.Context, r R) error,
+	bodyF func(ctx context.Context, r R) rop.Result[Out]) rop.Result[Out] {
+
+	releaseCtx := context.WithoutCancel(ctx)
+
+	released := false
+	defer func() {
+		if !released { // bodyF panicked
+			_ = releaseF(releaseCtx, resource)
+		}
+	}()
+
+	out := bodyF(ctx, resource)
+	released = true
+
+	return withReleaseErr(out, releaseF(releaseCtx, resource))
+}
+
+func withReleaseErr[Out any](out rop.Result[Out], releaseErr error) rop.Result[Out] {
+
+	if releaseErr == nil {
+		return out
+	}
+
+	if out.IsSuccess() {
+		return rop.Carry(out, rop.Fail[Out](releaseErr))
+	}
+
+	if out.IsCancel() {
+		return rop.Carry(out, rop.Cancel[Out](errors.Join(out.Err(), releaseErr)))
+	}
+	return rop.Carry(out, rop.Fail[Out](errors.Join(out.Err(), releaseErr)))
+}
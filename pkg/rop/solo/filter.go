@@ -0,0 +1,34 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+)
+
+var ErrFiltered = errors.New("filtered out")
+
+// Filter filters out a successful input rejected by keepF, see rop.Filtered.
+// Unlike Check it does not fail the input: the Finally functions return the
+// zero Out for it without calling a handler.
+func Filter[T any](input rop.Result[T], keepF func(r T) bool) rop.Result[T] {
+
+	if input.IsSuccess() && !keepF(input.Result()) {
+		return rop.Carry(input, rop.Filtered[T](ErrFiltered))
+	}
+	return input
+}
+
+func FilterWithCtx[T any](ctx context.Context, input rop.Result[T],
+	keepF func(ctx context.Context, r T) bool) rop.Result[T] {
+
+	if input.IsSuccess() {
+		return rop.Invoke(ctx, "filter", input, func(ctx context.Context) rop.Result[T] {
+			if keepF(rop.WithResultOf(ctx, input), input.Result()) {
+				return input
+			}
+			return rop.Filtered[T](ErrFiltered)
+		})
+	}
+	return input
+}
@@ -0,0 +1,21 @@
+package solo
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+)
+
+// Lift applies op to the input, or cancels the input with op when ctx is
+// already done. The call of op.Apply goes through the interceptors
+// of ctx and is recorded as a "lift" stage.
+func Lift[In, Out any](ctx context.Context, input rop.Result[In], op rop.Operator[In, Out]) rop.Result[Out] {
+
+	if ctx.Err() != nil {
+		return rop.Intercept(ctx, "cancel", input, func(ctx context.Context) rop.Result[Out] {
+			return op.Cancel(ctx, input)
+		})
+	}
+	return rop.Invoke(ctx, "lift", input, func(ctx context.Context) rop.Result[Out] {
+		return op.Apply(ctx, input)
+	})
+}
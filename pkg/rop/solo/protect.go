@@ -0,0 +1,18 @@
+package solo
+
+import (
+	"github.com/ib-77/rop/pkg/rop"
+)
+
+// Protect runs stepF and turns a panic inside it into rop.Fail with a
+// *rop.PanicError.
+func Protect[Out any](stepF func() rop.Result[Out]) (out rop.Result[Out]) {
+
+	defer func() {
+		if v := recover(); v != nil {
+			out = rop.Fail[Out](rop.NewPanicError(v))
+		}
+	}()
+
+	return stepF()
+}
@@ -0,0 +1,101 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+)
+
+// ErrorIs returns a matchF accepting errors that match target with errors.Is.
+func ErrorIs(target error) func(err error) bool {
+	return func(err error) bool {
+		return errors.Is(err, target)
+	}
+}
+
+// ErrorAs returns a matchF accepting errors that have an E in their chain.
+func ErrorAs[E error]() func(err error) bool {
+	return func(err error) bool {
+		var target E
+		return errors.As(err, &target)
+	}
+}
+
+// Recover brings a failed input matching matchF back onto the success track
+// with the value built by recoverF. A nil matchF matches every error.
+// Cancelled inputs are not recovered.
+func Recover[T any](input rop.Result[T], recoverF func(err error) T,
+	matchF func(err error) bool) rop.Result[T] {
+
+	if isRecoverable(input, matchF) {
+		return rop.Carry(input, rop.Success(recoverF(input.Err())))
+	}
+	return input
+}
+
+func RecoverWithCtx[T any](ctx context.Context, input rop.Result[T],
+	recoverF func(ctx context.Context, err error) T, matchF func(err error) bool) rop.Result[T] {
+
+	if isRecoverable(input, matchF) {
+		return rop.Invoke(ctx, "recover", input, func(ctx context.Context) rop.Result[T] {
+			return rop.Success(recoverF(rop.WithResultOf(ctx, input), input.Err()))
+		})
+	}
+	return input
+}
+
+// RecoverWith switches a failed input matching matchF to the result of the
+// alternative altF, which may fail again.
+func RecoverWith[T any](input rop.Result[T], altF func(err error) rop.Result[T],
+	matchF func(err error) bool) rop.Result[T] {
+
+	if isRecoverable(input, matchF) {
+		return rop.Carry(input, altF(input.Err()))
+	}
+	return input
+}
+
+func RecoverWithWithCtx[T any](ctx context.Context, input rop.Result[T],
+	altF func(ctx context.Context, err error) rop.Result[T], matchF func(err error) bool) rop.Result[T] {
+
+	if isRecoverable(input, matchF) {
+		return rop.Invoke(ctx, "recover", input, func(ctx context.Context) rop.Result[T] {
+			return altF(rop.WithResultOf(ctx, input), input.Err())
+		})
+	}
+	return input
+}
+
+// OrElse replaces a failed input matching matchF with fallback.
+func OrElse[T any](input rop.Result[T], fallback T, matchF func(err error) bool) rop.Result[T] {
+
+	if isRecoverable(input, matchF) {
+		return rop.Carry(input, rop.Success(fallback))
+	}
+	return input
+}
+
+// FailToCancel moves a failed input matching matchF to the cancel track.
+func FailToCancel[T any](input rop.Result[T], matchF func(err error) bool) rop.Result[T] {
+
+	if isRecoverable(input, matchF) {
+		return rop.Carry(input, rop.Cancel[T](input.Err()))
+	}
+	return input
+}
+
+// CancelToFail moves a cancelled input matching matchF to the fail track.
+func CancelToFail[T any](input rop.Result[T], matchF func(err error) bool) rop.Result[T] {
+
+	if input.IsCancel() && (matchF == nil || matchF(input.Err())) {
+		return rop.Carry(input, rop.Fail[T](input.Err()))
+	}
+	return input
+}
+
+func isRecoverable[T any](input rop.Result[T], matchF func(err error) bool) bool {
+	if input.IsSuccess() || input.IsCancel() || input.IsFiltered() {
+		return false
+	}
+	return matchF == nil || matchF(input.Err())
+}
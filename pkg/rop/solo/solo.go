@@ -20,11 +20,13 @@ func Validate[T any](input T, validateF func(in T) bool, errMsg string) rop.Resu
 func ValidateWithCtx[T any](ctx context.Context, input T,
 	validateF func(ctx context.Context, in T) bool, errMsg string) rop.Result[T] {
 
-	if validateF(ctx, input) {
-		return rop.Success(input)
-	} else {
-		return rop.Fail[T](errors.New(errMsg))
-	}
+	return rop.Invoke(ctx, "validate", rop.Success(input), func(ctx context.Context) rop.Result[T] {
+		if validateF(ctx, input) {
+			return rop.Success(input)
+		} else {
+			return rop.Fail[T](errors.New(errMsg))
+		}
+	})
 }
 
 func ValidateWithErr[T any](input T, validateF func(in T) (bool, error)) rop.Result[T] {
@@ -39,11 +41,13 @@ func ValidateWithErr[T any](input T, validateF func(in T) (bool, error)) rop.Res
 func ValidateWithErrWithCtx[T any](ctx context.Context, input T,
 	validateF func(ctx context.Context, in T) (bool, error)) rop.Result[T] {
 
-	if ok, err := validateF(ctx, input); ok {
-		return rop.Success(input)
-	} else {
-		return rop.Fail[T](err)
-	}
+	return rop.Invoke(ctx, "validate", rop.Success(input), func(ctx context.Context) rop.Result[T] {
+		if ok, err := validateF(ctx, input); ok {
+			return rop.Success(input)
+		} else {
+			return rop.Fail[T](err)
+		}
+	})
 }
 
 func ValidateCancel[T any](input T, validateF func(in T) bool, cancelMsg string) rop.Result[T] {
@@ -58,11 +62,13 @@ func ValidateCancel[T any](input T, validateF func(in T) bool, cancelMsg string)
 func ValidateCancelWithCtx[T any](ctx context.Context, input T,
 	validateF func(ctx context.Context, in T) bool, cancelMsg string) rop.Result[T] {
 
-	if validateF(ctx, input) {
-		return rop.Success(input)
-	} else {
-		return rop.Cancel[T](errors.New(cancelMsg))
-	}
+	return rop.Invoke(ctx, "validate", rop.Success(input), func(ctx context.Context) rop.Result[T] {
+		if validateF(ctx, input) {
+			return rop.Success(input)
+		} else {
+			return rop.Cancel[T](errors.New(cancelMsg))
+		}
+	})
 }
 
 func ValidateCancelWithErr[T any](input T, validateF func(in T) (bool, error)) rop.Result[T] {
@@ -79,9 +85,9 @@ func AndValidate[T any](input rop.Result[T], validateF func(in T) bool, errMsg s
 	if input.IsSuccess() {
 
 		if validateF(input.Result()) {
-			return rop.Success(input.Result())
+			return rop.Carry(input, rop.Success(input.Result()))
 		} else {
-			return rop.Fail[T](errors.New(errMsg))
+			return rop.Carry(input, rop.Fail[T](errors.New(errMsg)))
 		}
 	}
 	return input
@@ -91,12 +97,13 @@ func AndValidateWithCtx[T any](ctx context.Context, input rop.Result[T],
 	validateF func(ctx context.Context, in T) bool, errMsg string) rop.Result[T] {
 
 	if input.IsSuccess() {
-
-		if validateF(ctx, input.Result()) {
-			return rop.Success(input.Result())
-		} else {
-			return rop.Fail[T](errors.New(errMsg))
-		}
+		return rop.Invoke(ctx, "validate", input, func(ctx context.Context) rop.Result[T] {
+			if validateF(rop.WithResultOf(ctx, input), input.Result()) {
+				return rop.Success(input.Result())
+			} else {
+				return rop.Fail[T](errors.New(errMsg))
+			}
+		})
 	}
 	return input
 }
@@ -106,9 +113,9 @@ func AndValidateWithErr[T any](input rop.Result[T], validateF func(in T) (bool,
 	if input.IsSuccess() {
 
 		if ok, err := validateF(input.Result()); ok {
-			return rop.Success(input.Result())
+			return rop.Carry(input, rop.Success(input.Result()))
 		} else {
-			return rop.Fail[T](err)
+			return rop.Carry(input, rop.Fail[T](err))
 		}
 	}
 	return input
@@ -118,12 +125,13 @@ func AndValidateWithErrWithCtx[T any](ctx context.Context, input rop.Result[T],
 	validateF func(ctx context.Context, in T) (bool, error)) rop.Result[T] {
 
 	if input.IsSuccess() {
-
-		if ok, err := validateF(ctx, input.Result()); ok {
-			return rop.Success(input.Result())
-		} else {
-			return rop.Fail[T](err)
-		}
+		return rop.Invoke(ctx, "validate", input, func(ctx context.Context) rop.Result[T] {
+			if ok, err := validateF(rop.WithResultOf(ctx, input), input.Result()); ok {
+				return rop.Success(input.Result())
+			} else {
+				return rop.Fail[T](err)
+			}
+		})
 	}
 	return input
 }
@@ -132,9 +140,9 @@ func AndValidateCancelWithErr[T any](input rop.Result[T], validateF func(in T) (
 
 	if input.IsSuccess() {
 		if ok, cancelErr := validateF(input.Result()); ok {
-			return rop.Success(input.Result())
+			return rop.Carry(input, rop.Success(input.Result()))
 		} else {
-			return rop.Cancel[T](cancelErr)
+			return rop.Carry(input, rop.Cancel[T](cancelErr))
 		}
 	}
 	return input
@@ -144,11 +152,13 @@ func AndValidateCancelWithCtx[T any](ctx context.Context, input rop.Result[T],
 	validateF func(ctx context.Context, in T) bool, cancelMsg string) rop.Result[T] {
 
 	if input.IsSuccess() {
-		if ok := validateF(ctx, input.Result()); ok {
-			return rop.Success(input.Result())
-		} else {
-			return rop.Cancel[T](fmt.Errorf(cancelMsg))
-		}
+		return rop.Invoke(ctx, "validate", input, func(ctx context.Context) rop.Result[T] {
+			if ok := validateF(rop.WithResultOf(ctx, input), input.Result()); ok {
+				return rop.Success(input.Result())
+			} else {
+				return rop.Cancel[T](fmt.Errorf(cancelMsg))
+			}
+		})
 	}
 	return input
 }
@@ -156,12 +166,12 @@ func AndValidateCancelWithCtx[T any](ctx context.Context, input rop.Result[T],
 func Switch[In any, Out any](input rop.Result[In], switchF func(r In) rop.Result[Out]) rop.Result[Out] {
 
 	if input.IsSuccess() {
-		return switchF(input.Result())
+		return rop.Carry(input, switchF(input.Result()))
 	} else {
 		if input.IsCancel() {
-			return rop.Cancel[Out](input.Err())
+			return rop.Carry(input, rop.Cancel[Out](input.Err()))
 		} else {
-			return rop.Fail[Out](input.Err())
+			return rop.Carry(input, rop.Fail[Out](input.Err()))
 		}
 	}
 }
@@ -170,12 +180,14 @@ func SwitchWithCtx[In any, Out any](ctx context.Context,
 	input rop.Result[In], switchF func(ctx context.Context, r In) rop.Result[Out]) rop.Result[Out] {
 
 	if input.IsSuccess() {
-		return switchF(ctx, input.Result())
+		return rop.Invoke(ctx, "switch", input, func(ctx context.Context) rop.Result[Out] {
+			return switchF(rop.WithResultOf(ctx, input), input.Result())
+		})
 	} else {
 		if input.IsCancel() {
-			return rop.Cancel[Out](input.Err())
+			return rop.Carry(input, rop.Cancel[Out](input.Err()))
 		} else {
-			return rop.Fail[Out](input.Err())
+			return rop.Carry(input, rop.Fail[Out](input.Err()))
 		}
 	}
 }
@@ -183,12 +195,12 @@ func SwitchWithCtx[In any, Out any](ctx context.Context,
 func Map[In any, Out any](input rop.Result[In], mapF func(r In) Out) rop.Result[Out] {
 
 	if input.IsSuccess() {
-		return rop.Success(mapF(input.Result()))
+		return rop.Carry(input, rop.Success(mapF(input.Result())))
 	} else {
 		if input.IsCancel() {
-			return rop.Cancel[Out](input.Err())
+			return rop.Carry(input, rop.Cancel[Out](input.Err()))
 		} else {
-			return rop.Fail[Out](input.Err())
+			return rop.Carry(input, rop.Fail[Out](input.Err()))
 		}
 	}
 }
@@ -197,12 +209,14 @@ func MapWithCtx[In any, Out any](ctx context.Context,
 	input rop.Result[In], mapF func(ctx context.Context, r In) Out) rop.Result[Out] {
 
 	if input.IsSuccess() {
-		return rop.Success(mapF(ctx, input.Result()))
+		return rop.Invoke(ctx, "map", input, func(ctx context.Context) rop.Result[Out] {
+			return rop.Success(mapF(rop.WithResultOf(ctx, input), input.Result()))
+		})
 	} else {
 		if input.IsCancel() {
-			return rop.Cancel[Out](input.Err())
+			return rop.Carry(input, rop.Cancel[Out](input.Err()))
 		} else {
-			return rop.Fail[Out](input.Err())
+			return rop.Carry(input, rop.Fail[Out](input.Err()))
 		}
 	}
 }
@@ -211,16 +225,18 @@ func MapWithErrWithCtx[In any, Out any](ctx context.Context,
 	input rop.Result[In], mapF func(ctx context.Context, r In) (Out, error)) rop.Result[Out] {
 
 	if input.IsSuccess() {
-		r, err := mapF(ctx, input.Result())
-		if err != nil {
-			return rop.Fail[Out](err)
-		}
-		return rop.Success(r)
+		return rop.Invoke(ctx, "map", input, func(ctx context.Context) rop.Result[Out] {
+			r, err := mapF(rop.WithResultOf(ctx, input), input.Result())
+			if err != nil {
+				return rop.Fail[Out](err)
+			}
+			return rop.Success(r)
+		})
 	} else {
 		if input.IsCancel() {
-			return rop.Cancel[Out](input.Err())
+			return rop.Carry(input, rop.Cancel[Out](input.Err()))
 		} else {
-			return rop.Fail[Out](input.Err())
+			return rop.Carry(input, rop.Fail[Out](input.Err()))
 		}
 	}
 }
@@ -240,7 +256,7 @@ func TeeWithErr[T any](input rop.Result[T], deadEndF func(r rop.Result[T]) error
 	if input.IsSuccess() {
 		err := deadEndF(input)
 		if err != nil {
-			return rop.Fail[T](err)
+			return rop.Carry(input, rop.Fail[T](err))
 		}
 	}
 
@@ -251,10 +267,13 @@ func TeeWithErrWithCtx[T any](ctx context.Context, input rop.Result[T],
 	deadEndF func(ctx context.Context, r rop.Result[T]) error) rop.Result[T] {
 
 	if input.IsSuccess() {
-		err := deadEndF(ctx, input)
-		if err != nil {
-			return rop.Fail[T](err)
-		}
+		return rop.Invoke(ctx, "tee", input, func(ctx context.Context) rop.Result[T] {
+			err := deadEndF(rop.WithResultOf(ctx, input), input)
+			if err != nil {
+				return rop.Fail[T](err)
+			}
+			return input
+		})
 	}
 
 	return input
@@ -264,7 +283,10 @@ func TeeWithCtx[T any](ctx context.Context, input rop.Result[T],
 	deadEndF func(ctx context.Context, r rop.Result[T])) rop.Result[T] {
 
 	if input.IsSuccess() {
-		deadEndF(ctx, input)
+		return rop.Invoke(ctx, "tee", input, func(ctx context.Context) rop.Result[T] {
+			deadEndF(rop.WithResultOf(ctx, input), input)
+			return input
+		})
 	}
 
 	return input
@@ -276,7 +298,7 @@ func DoubleTee[T any](input rop.Result[T], deadEndF func(r T),
 
 	if input.IsSuccess() {
 		deadEndF(input.Result())
-	} else {
+	} else if !input.IsFiltered() {
 		deadEndWithErrF(input.Err())
 	}
 
@@ -288,9 +310,15 @@ func DoubleTeeWithCtx[T any](ctx context.Context, input rop.Result[T],
 	deadEndWithErrF func(ctx context.Context, err error)) rop.Result[T] {
 
 	if input.IsSuccess() {
-		deadEndF(ctx, input.Result())
-	} else {
-		deadEndWithErrF(ctx, input.Err())
+		return rop.Invoke(ctx, "tee", input, func(ctx context.Context) rop.Result[T] {
+			deadEndF(rop.WithResultOf(ctx, input), input.Result())
+			return input
+		})
+	} else if !input.IsFiltered() {
+		return rop.Intercept(ctx, "tee", input, func(ctx context.Context) rop.Result[T] {
+			deadEndWithErrF(rop.WithResultOf(ctx, input), input.Err())
+			return input
+		})
 	}
 
 	return input
@@ -300,19 +328,19 @@ func DoubleMap[In any, Out any](input rop.Result[In], successF func(r In) Out,
 	failF func(err error) Out, cancelF func(err error) Out) rop.Result[Out] {
 
 	if input.IsSuccess() {
-		return rop.Success(successF(input.Result()))
+		return rop.Carry(input, rop.Success(successF(input.Result())))
 	}
 
 	if input.IsCancel() {
 		cancelF(input.Err())
-	} else {
+	} else if !input.IsFiltered() {
 		failF(input.Err())
 	}
 
 	if input.IsCancel() {
-		return rop.Cancel[Out](input.Err())
+		return rop.Carry(input, rop.Cancel[Out](input.Err()))
 	} else {
-		return rop.Fail[Out](input.Err())
+		return rop.Carry(input, rop.Fail[Out](input.Err()))
 	}
 }
 
@@ -321,20 +349,23 @@ func DoubleMapWithCtx[In any, Out any](ctx context.Context, input rop.Result[In]
 	cancelF func(ctx context.Context, err error) Out) rop.Result[Out] {
 
 	if input.IsSuccess() {
-		return rop.Success(successF(ctx, input.Result()))
+		return rop.Invoke(ctx, "map", input, func(ctx context.Context) rop.Result[Out] {
+			return rop.Success(successF(rop.WithResultOf(ctx, input), input.Result()))
+		})
 	}
 
-	if input.IsCancel() {
-		cancelF(ctx, input.Err())
-	} else {
-		failF(ctx, input.Err())
+	if input.IsFiltered() {
+		return rop.Carry(input, rop.Fail[Out](input.Err()))
 	}
 
-	if input.IsCancel() {
-		return rop.Cancel[Out](input.Err())
-	} else {
-		return rop.Fail[Out](input.Err())
-	}
+	return rop.Intercept(ctx, "map", input, func(ctx context.Context) rop.Result[Out] {
+		if input.IsCancel() {
+			cancelF(rop.WithResultOf(ctx, input), input.Err())
+			return rop.Carry(input, rop.Cancel[Out](input.Err()))
+		}
+		failF(rop.WithResultOf(ctx, input), input.Err())
+		return rop.Carry(input, rop.Fail[Out](input.Err()))
+	})
 }
 
 func Try[In any, Out any](input rop.Result[In], withErrF func(r In) (Out, error)) rop.Result[Out] {
@@ -343,16 +374,16 @@ func Try[In any, Out any](input rop.Result[In], withErrF func(r In) (Out, error)
 
 		out, err := withErrF(input.Result())
 		if err != nil {
-			return rop.Fail[Out](err)
+			return rop.Carry(input, rop.Fail[Out](err))
 		}
 
-		return rop.Success(out)
+		return rop.Carry(input, rop.Success(out))
 	}
 
 	if input.IsCancel() {
-		return rop.Cancel[Out](input.Err())
+		return rop.Carry(input, rop.Cancel[Out](input.Err()))
 	} else {
-		return rop.Fail[Out](input.Err())
+		return rop.Carry(input, rop.Fail[Out](input.Err()))
 	}
 }
 
@@ -361,18 +392,20 @@ func TryWithCtx[In any, Out any](ctx context.Context, input rop.Result[In],
 
 	if input.IsSuccess() {
 
-		out, err := withErrF(ctx, input.Result())
-		if err != nil {
-			return rop.Fail[Out](err)
-		}
+		return rop.Invoke(ctx, "try", input, func(ctx context.Context) rop.Result[Out] {
+			out, err := withErrF(rop.WithResultOf(ctx, input), input.Result())
+			if err != nil {
+				return rop.Fail[Out](err)
+			}
 
-		return rop.Success(out)
+			return rop.Success(out)
+		})
 	}
 
 	if input.IsCancel() {
-		return rop.Cancel[Out](input.Err())
+		return rop.Carry(input, rop.Cancel[Out](input.Err()))
 	} else {
-		return rop.Fail[Out](input.Err())
+		return rop.Carry(input, rop.Fail[Out](input.Err()))
 	}
 }
 
@@ -383,15 +416,21 @@ func RetryWithCtx[In any, Out any](ctx context.Context, input rop.Result[In],
 
 		rs, ok := rop.GetRetryFromCtx(ctx)
 		if !ok {
-			return rop.Fail[Out](fmt.Errorf("RetryWithCtx: context  is not set, use rop.WithRetry"))
+			return rop.Step(ctx, "retry", input,
+				rop.Fail[Out](fmt.Errorf("RetryWithCtx: context  is not set, use rop.WithRetry")))
 		}
 
 		var attempt int64 = 0
-		var err error
-		var out Out
+		var res rop.Result[Out]
 		for {
-			out, err = withErrF(ctx, input.Result())
-			if err != nil {
+			res = rop.Intercept(ctx, "retry", input, func(ctx context.Context) rop.Result[Out] {
+				out, err := withErrF(rop.WithResultOf(ctx, input), input.Result())
+				if err != nil {
+					return rop.Fail[Out](err)
+				}
+				return rop.Success(out)
+			})
+			if !res.IsSuccess() {
 				attempt++
 				if attempt >= rs.Attempts() {
 					break
@@ -402,17 +441,13 @@ func RetryWithCtx[In any, Out any](ctx context.Context, input rop.Result[In],
 			}
 		}
 
-		if err != nil {
-			return rop.Fail[Out](err)
-		}
-
-		return rop.Success(out)
+		return rop.Step(ctx, "retry", input, res)
 	}
 
 	if input.IsCancel() {
-		return rop.Cancel[Out](input.Err())
+		return rop.Carry(input, rop.Cancel[Out](input.Err()))
 	} else {
-		return rop.Fail[Out](input.Err())
+		return rop.Carry(input, rop.Fail[Out](input.Err()))
 	}
 }
 
@@ -422,16 +457,16 @@ func Check[In any](input rop.Result[In], boolF func(r In) bool, falseErrMsg stri
 	if input.IsSuccess() {
 
 		if ok := boolF(input.Result()); ok {
-			return rop.Success[bool](true)
+			return rop.Carry(input, rop.Success[bool](true))
 		} else {
-			return rop.Fail[bool](errors.New(falseErrMsg))
+			return rop.Carry(input, rop.Fail[bool](errors.New(falseErrMsg)))
 		}
 	}
 
 	if input.IsCancel() {
-		return rop.Cancel[bool](input.Err())
+		return rop.Carry(input, rop.Cancel[bool](input.Err()))
 	} else {
-		return rop.Fail[bool](input.Err())
+		return rop.Carry(input, rop.Fail[bool](input.Err()))
 	}
 }
 
@@ -440,17 +475,19 @@ func CheckWithCtx[In any](ctx context.Context, input rop.Result[In],
 
 	if input.IsSuccess() {
 
-		if ok := boolF(ctx, input.Result()); ok {
-			return rop.Success[bool](true)
-		} else {
-			return rop.Fail[bool](errors.New(falseErrMsg))
-		}
+		return rop.Invoke(ctx, "check", input, func(ctx context.Context) rop.Result[bool] {
+			if ok := boolF(rop.WithResultOf(ctx, input), input.Result()); ok {
+				return rop.Success[bool](true)
+			} else {
+				return rop.Fail[bool](errors.New(falseErrMsg))
+			}
+		})
 	}
 
 	if input.IsCancel() {
-		return rop.Cancel[bool](input.Err())
+		return rop.Carry(input, rop.Cancel[bool](input.Err()))
 	} else {
-		return rop.Fail[bool](input.Err())
+		return rop.Carry(input, rop.Fail[bool](input.Err()))
 	}
 }
 
@@ -460,16 +497,16 @@ func CheckCancel[In any](input rop.Result[In], boolF func(r In) bool, falseCance
 	if input.IsSuccess() {
 
 		if ok := boolF(input.Result()); ok {
-			return rop.Success[bool](true)
+			return rop.Carry(input, rop.Success[bool](true))
 		} else {
-			return rop.Cancel[bool](errors.New(falseCancelMsg))
+			return rop.Carry(input, rop.Cancel[bool](errors.New(falseCancelMsg)))
 		}
 	}
 
 	if input.IsCancel() {
-		return rop.Cancel[bool](input.Err())
+		return rop.Carry(input, rop.Cancel[bool](input.Err()))
 	} else {
-		return rop.Fail[bool](input.Err())
+		return rop.Carry(input, rop.Fail[bool](input.Err()))
 	}
 }
 
@@ -478,17 +515,19 @@ func CheckCancelWithCtx[In any](ctx context.Context, input rop.Result[In],
 
 	if input.IsSuccess() {
 
-		if ok := boolF(ctx, input.Result()); ok {
-			return rop.Success[bool](true)
-		} else {
-			return rop.Cancel[bool](errors.New(falseCancelMsg))
-		}
+		return rop.Invoke(ctx, "check", input, func(ctx context.Context) rop.Result[bool] {
+			if ok := boolF(rop.WithResultOf(ctx, input), input.Result()); ok {
+				return rop.Success[bool](true)
+			} else {
+				return rop.Cancel[bool](errors.New(falseCancelMsg))
+			}
+		})
 	}
 
 	if input.IsCancel() {
-		return rop.Cancel[bool](input.Err())
+		return rop.Carry(input, rop.Cancel[bool](input.Err()))
 	} else {
-		return rop.Fail[bool](input.Err())
+		return rop.Carry(input, rop.Fail[bool](input.Err()))
 	}
 }
 
@@ -496,6 +535,9 @@ func Finally[Out, In any](input rop.Result[In], successF func(r In) Out,
 	failOrCancelF func(err error) Out) Out {
 	if input.IsSuccess() {
 		return successF(input.Result())
+	} else if input.IsFiltered() {
+		var skipped Out
+		return skipped
 	} else {
 		return failOrCancelF(input.Err())
 	}
@@ -504,34 +546,56 @@ func Finally[Out, In any](input rop.Result[In], successF func(r In) Out,
 func FinallyWithCtx[Out, In any](ctx context.Context, input rop.Result[In],
 	successF func(ctx context.Context, r In) Out, failOrCancelF func(ctx context.Context, err error) Out) Out {
 	if input.IsSuccess() {
-		return successF(ctx, input.Result())
+		return finally(ctx, input, func(ctx context.Context) Out {
+			return successF(ctx, input.Result())
+		})
+	} else if input.IsFiltered() {
+		var skipped Out
+		return skipped
 	} else {
-		return failOrCancelF(ctx, input.Err())
+		return finally(ctx, input, func(ctx context.Context) Out {
+			return failOrCancelF(ctx, input.Err())
+		})
 	}
 }
 
 func FinallyTeeWithCtx[In any](ctx context.Context, input rop.Result[In],
 	successF func(ctx context.Context, r In), failOrCancelF func(ctx context.Context, err error)) {
 	if input.IsSuccess() {
-		successF(ctx, input.Result())
-	} else {
-		failOrCancelF(ctx, input.Err())
+		finally(ctx, input, func(ctx context.Context) struct{} {
+			successF(ctx, input.Result())
+			return struct{}{}
+		})
+	} else if !input.IsFiltered() {
+		finally(ctx, input, func(ctx context.Context) struct{} {
+			failOrCancelF(ctx, input.Err())
+			return struct{}{}
+		})
 	}
 }
 
 func FinallyTeeWithCtxWithErr[In any](ctx context.Context, input rop.Result[In],
 	successF func(ctx context.Context, r In) error, failOrCancelF func(ctx context.Context, err error) error) error {
+	var err error
 	if input.IsSuccess() {
-		return successF(ctx, input.Result())
-	} else {
-		return failOrCancelF(ctx, input.Err())
+		_, err = finallyWithErr(ctx, input, func(ctx context.Context) (struct{}, error) {
+			return struct{}{}, successF(ctx, input.Result())
+		})
+	} else if !input.IsFiltered() {
+		_, err = finallyWithErr(ctx, input, func(ctx context.Context) (struct{}, error) {
+			return struct{}{}, failOrCancelF(ctx, input.Err())
+		})
 	}
+	return err
 }
 
 func FinallyWithErr[Out, In any](input rop.Result[In], successF func(r In) (Out, error),
 	failOrCancelF func(err error) (Out, error)) (Out, error) {
 	if input.IsSuccess() {
 		return successF(input.Result())
+	} else if input.IsFiltered() {
+		var skipped Out
+		return skipped, nil
 	} else {
 		return failOrCancelF(input.Err())
 	}
@@ -541,14 +605,50 @@ func FinallyWithCtxWithErr[Out, In any](ctx context.Context,
 	input rop.Result[In], successF func(ctx context.Context, r In) (Out, error),
 	failOrCancelF func(ctx context.Context, err error) (Out, error)) (Out, error) {
 	if input.IsSuccess() {
-		return successF(ctx, input.Result())
+		return finallyWithErr(ctx, input, func(ctx context.Context) (Out, error) {
+			return successF(ctx, input.Result())
+		})
+	} else if input.IsFiltered() {
+		var skipped Out
+		return skipped, nil
 	} else {
-		return failOrCancelF(ctx, input.Err())
+		return finallyWithErr(ctx, input, func(ctx context.Context) (Out, error) {
+			return failOrCancelF(ctx, input.Err())
+		})
 	}
 }
 
+// finally runs the handler f of a Finally function through the interceptors
+// of ctx, they see the input as the outcome.
+func finally[In, Out any](ctx context.Context, input rop.Result[In],
+	f func(ctx context.Context) Out) Out {
+
+	var out Out
+	rop.Intercept(ctx, "finally", input, func(ctx context.Context) rop.Result[In] {
+		out = f(rop.WithResultOf(ctx, input))
+		return input
+	})
+	return out
+}
+
+// finallyWithErr is finally for a handler that can fail, its error is the
+// outcome the interceptors see.
+func finallyWithErr[In, Out any](ctx context.Context, input rop.Result[In],
+	f func(ctx context.Context) (Out, error)) (Out, error) {
+
+	var out Out
+	var err error
+	rop.Intercept(ctx, "finally", input, func(ctx context.Context) rop.Result[In] {
+		if out, err = f(rop.WithResultOf(ctx, input)); err != nil {
+			return rop.Carry(input, rop.Fail[In](err))
+		}
+		return input
+	})
+	return out, err
+}
+
 func SucceedWith[In any, Out any](input rop.Result[In], successF func(r In) Out) rop.Result[Out] {
-	return rop.Success(successF(input.Result()))
+	return rop.Carry(input, rop.Success(successF(input.Result())))
 }
 
 func Succeed[In any](input In) rop.Result[In] {
@@ -557,19 +657,19 @@ func Succeed[In any](input In) rop.Result[In] {
 
 func SucceedWithCtx[In any, Out any](ctx context.Context, input rop.Result[In],
 	successF func(ctx context.Context, r In) Out) rop.Result[Out] {
-	return rop.Success(successF(ctx, input.Result()))
+	return rop.Carry(input, rop.Success(successF(rop.WithResultOf(ctx, input), input.Result())))
 }
 
 func FailWith[In any, Out any](input rop.Result[In], failF func(r rop.Result[In]) error) rop.Result[Out] {
 	if input.IsSuccess() {
-		return rop.Fail[Out](failF(input))
+		return rop.Carry(input, rop.Fail[Out](failF(input)))
 	}
 
 	if input.IsCancel() {
-		return rop.Cancel[Out](input.Err()) // strange, is already canceled!
+		return rop.Carry(input, rop.Cancel[Out](input.Err())) // strange, is already canceled!
 	}
 
-	return rop.Fail[Out](input.Err()) // strange, is already failed
+	return rop.Carry(input, rop.Fail[Out](input.Err())) // strange, is already failed
 }
 
 func Fail[In any](err error) rop.Result[In] {
@@ -580,26 +680,26 @@ func FailWithCtx[In any, Out any](ctx context.Context, input rop.Result[In],
 	failF func(ctx context.Context, r rop.Result[In]) error) rop.Result[Out] {
 
 	if input.IsSuccess() {
-		return rop.Fail[Out](failF(ctx, input))
+		return rop.Carry(input, rop.Fail[Out](failF(rop.WithResultOf(ctx, input), input)))
 	}
 
 	if input.IsCancel() {
-		return rop.Cancel[Out](input.Err()) // strange, is already canceled!
+		return rop.Carry(input, rop.Cancel[Out](input.Err())) // strange, is already canceled!
 	}
 
-	return rop.Fail[Out](input.Err()) // strange, is already failed
+	return rop.Carry(input, rop.Fail[Out](input.Err())) // strange, is already failed
 }
 
 func CancelWith[In any, Out any](input rop.Result[In], cancelF func(r rop.Result[In]) error) rop.Result[Out] { // cancelF out
 	if input.IsSuccess() {
-		return rop.Cancel[Out](cancelF(input))
+		return rop.Carry(input, rop.Cancel[Out](cancelF(input)))
 	}
 
 	if input.IsCancel() {
-		return rop.Cancel[Out](input.Err())
+		return rop.Carry(input, rop.Cancel[Out](input.Err()))
 	}
 
-	return rop.Fail[Out](input.Err())
+	return rop.Carry(input, rop.Fail[Out](input.Err()))
 }
 
 func Cancel[In any](err error) rop.Result[In] {
@@ -610,12 +710,14 @@ func CancelWithCtx[In any, Out any](ctx context.Context, input rop.Result[In],
 	cancelF func(ctx context.Context, r In) error) rop.Result[Out] {
 
 	if input.IsSuccess() {
-		return rop.Cancel[Out](cancelF(ctx, input.Result()))
+		return rop.Intercept(ctx, "cancel", input, func(ctx context.Context) rop.Result[Out] {
+			return rop.Carry(input, rop.CancelFromCtx[Out](ctx, cancelF(rop.WithResultOf(ctx, input), input.Result())))
+		})
 	}
 
 	if input.IsCancel() {
-		return rop.Cancel[Out](input.Err())
+		return rop.Carry(input, rop.Cancel[Out](input.Err()))
 	}
 
-	return rop.Fail[Out](input.Err())
+	return rop.Carry(input, rop.Fail[Out](input.Err()))
 }
@@ -0,0 +1,123 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+	"time"
+)
+
+var ErrStepTimeout = errors.New("step timed out")
+
+// TimeoutPolicy decides how TimeoutWithCtx reports a step that ran out of time.
+type TimeoutPolicy int
+
+const (
+	// TimeoutFail turns a timed out step into rop.Fail wrapping ErrStepTimeout.
+	TimeoutFail TimeoutPolicy = iota
+	// TimeoutCancel turns a timed out step into rop.Cancel wrapping ErrStepTimeout.
+	TimeoutCancel
+)
+
+// Exhausted is the timeout SplitBudget gives a step that has no time left.
+const Exhausted time.Duration = -1
+
+// TimeoutWithCtx runs switchF under a deadline derived from ctx. When the step
+// does not return in time its result is abandoned and the policy decides the
+// outcome; when ctx itself is done first the result is rop.Cancel. A timeout
+// of 0 runs switchF without an extra deadline, a negative one such as
+// Exhausted times the step out without running switchF.
+func TimeoutWithCtx[In any, Out any](ctx context.Context, input rop.Result[In],
+	timeout time.Duration, policy TimeoutPolicy,
+	switchF func(ctx context.Context, r In) rop.Result[Out]) rop.Result[Out] {
+
+	if !input.IsSuccess() {
+		if input.IsCancel() {
+			return rop.Carry(input, rop.Cancel[Out](input.Err()))
+		} else {
+			return rop.Carry(input, rop.Fail[Out](input.Err()))
+		}
+	}
+
+	if timeout < 0 {
+		return rop.Step(ctx, "timeout", input,
+			timedOut[Out](ctx, policy, fmt.Errorf("%w: no time left", ErrStepTimeout)))
+	}
+
+	if timeout == 0 {
+		return rop.Invoke(ctx, "timeout", input, func(ctx context.Context) rop.Result[Out] {
+			return switchF(rop.WithResultOf(ctx, input), input.Result())
+		})
+	}
+
+	stepCtx, cancel := context.WithTimeoutCause(ctx, timeout, ErrStepTimeout)
+	defer cancel()
+
+	done := make(chan rop.Result[Out], 1) // the step may finish after we gave up
+	// nobody could recover a panic of this goroutine
+	go func() {
+		done <- Protect(func() rop.Result[Out] {
+			return rop.Intercept(stepCtx, "timeout", input, func(ctx context.Context) rop.Result[Out] {
+				return switchF(rop.WithResultOf(ctx, input), input.Result())
+			})
+		})
+	}()
+
+	select {
+	case out := <-done:
+		return rop.Step(ctx, "timeout", input, out)
+	case <-stepCtx.Done():
+	}
+
+	return rop.Step(ctx, "timeout", input,
+		timedOut[Out](ctx, policy, fmt.Errorf("%w after %v", ErrStepTimeout, timeout)))
+}
+
+func timedOut[Out any](ctx context.Context, policy TimeoutPolicy, err error) rop.Result[Out] {
+
+	if ctx.Err() != nil {
+		return rop.CancelFromCtx[Out](ctx, ctx.Err())
+	}
+
+	if policy == TimeoutCancel {
+		return rop.CancelBecause[Out](err, context.DeadlineExceeded)
+	}
+	return rop.Fail[Out](err)
+}
+
+// SplitBudget divides total between chained steps in proportion to weights,
+// so each step can get its own TimeoutWithCtx out of one overall deadline.
+// A step left without time, because total is used up or its share rounds
+// down to nothing, gets Exhausted. Without any weight every part is 0.
+func SplitBudget(total time.Duration, weights ...float64) []time.Duration {
+
+	sum := 0.0
+	for _, w := range weights {
+		sum += w
+	}
+
+	parts := make([]time.Duration, len(weights))
+	if sum <= 0 {
+		return parts
+	}
+
+	for i, w := range weights {
+		if parts[i] = time.Duration(float64(total) * w / sum); parts[i] <= 0 {
+			parts[i] = Exhausted
+		}
+	}
+	return parts
+}
+
+// SplitDeadline is SplitBudget over the time left until the deadline of ctx,
+// every part is Exhausted once it has passed. Without a deadline every part
+// is 0, which TimeoutWithCtx treats as no limit.
+func SplitDeadline(ctx context.Context, weights ...float64) []time.Duration {
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return make([]time.Duration, len(weights))
+	}
+	return SplitBudget(time.Until(deadline), weights...)
+}
@@ -0,0 +1,170 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+)
+
+// Zip2 combines independent results into one. The first input that is not
+// successful decides the outcome, its track and error are kept.
+func Zip2[T1, T2 any](r1 rop.Result[T1], r2 rop.Result[T2]) rop.Result[rop.Tuple2[T1, T2]] {
+
+	type Out = rop.Tuple2[T1, T2]
+	switch {
+	case !r1.IsSuccess():
+		return passOn[T1, Out](r1)
+	case !r2.IsSuccess():
+		return passOn[T2, Out](r2)
+	}
+
+	out := rop.Success(Out{V1: r1.Result(), V2: r2.Result()})
+	return rop.Carry(r1, rop.Carry(r2, out))
+}
+
+func Zip3[T1, T2, T3 any](r1 rop.Result[T1], r2 rop.Result[T2],
+	r3 rop.Result[T3]) rop.Result[rop.Tuple3[T1, T2, T3]] {
+
+	type Out = rop.Tuple3[T1, T2, T3]
+	switch {
+	case !r1.IsSuccess():
+		return passOn[T1, Out](r1)
+	case !r2.IsSuccess():
+		return passOn[T2, Out](r2)
+	case !r3.IsSuccess():
+		return passOn[T3, Out](r3)
+	}
+
+	out := rop.Success(Out{V1: r1.Result(), V2: r2.Result(), V3: r3.Result()})
+	return rop.Carry(r1, rop.Carry(r2, rop.Carry(r3, out)))
+}
+
+func Zip4[T1, T2, T3, T4 any](r1 rop.Result[T1], r2 rop.Result[T2],
+	r3 rop.Result[T3], r4 rop.Result[T4]) rop.Result[rop.Tuple4[T1, T2, T3, T4]] {
+
+	type Out = rop.Tuple4[T1, T2, T3, T4]
+	switch {
+	case !r1.IsSuccess():
+		return passOn[T1, Out](r1)
+	case !r2.IsSuccess():
+		return passOn[T2, Out](r2)
+	case !r3.IsSuccess():
+		return passOn[T3, Out](r3)
+	case !r4.IsSuccess():
+		return passOn[T4, Out](r4)
+	}
+
+	out := rop.Success(Out{V1: r1.Result(), V2: r2.Result(), V3: r3.Result(), V4: r4.Result()})
+	return rop.Carry(r1, rop.Carry(r2, rop.Carry(r3, rop.Carry(r4, out))))
+}
+
+func Zip5[T1, T2, T3, T4, T5 any](r1 rop.Result[T1], r2 rop.Result[T2], r3 rop.Result[T3],
+	r4 rop.Result[T4], r5 rop.Result[T5]) rop.Result[rop.Tuple5[T1, T2, T3, T4, T5]] {
+
+	type Out = rop.Tuple5[T1, T2, T3, T4, T5]
+	switch {
+	case !r1.IsSuccess():
+		return passOn[T1, Out](r1)
+	case !r2.IsSuccess():
+		return passOn[T2, Out](r2)
+	case !r3.IsSuccess():
+		return passOn[T3, Out](r3)
+	case !r4.IsSuccess():
+		return passOn[T4, Out](r4)
+	case !r5.IsSuccess():
+		return passOn[T5, Out](r5)
+	}
+
+	out := rop.Success(Out{V1: r1.Result(), V2: r2.Result(), V3: r3.Result(),
+		V4: r4.Result(), V5: r5.Result()})
+	return rop.Carry(r1, rop.Carry(r2, rop.Carry(r3, rop.Carry(r4, rop.Carry(r5, out)))))
+}
+
+// TraverseMode decides what Traverse does after the first unsuccessful item.
+type TraverseMode int
+
+const (
+	// FailFast stops at the first unsuccessful item and returns it.
+	FailFast TraverseMode = iota
+	// CollectAll processes every item and joins the errors of all failed
+	// and cancelled ones. The result is cancelled only if none failed.
+	CollectAll
+)
+
+// Sequence turns a slice of results into a result of their values. It stops
+// at the first unsuccessful input; filtered inputs are left out of the slice.
+func Sequence[T any](inputs []rop.Result[T]) rop.Result[[]T] {
+	return Traverse(inputs, func(r rop.Result[T]) rop.Result[T] {
+		return r
+	}, FailFast)
+}
+
+// Traverse applies f to every item and collects the successful values in
+// order. Filtered results are left out of the slice.
+func Traverse[In, Out any](items []In, f func(r In) rop.Result[Out],
+	mode TraverseMode) rop.Result[[]Out] {
+
+	return TraverseWithCtx(context.Background(), items,
+		func(_ context.Context, r In) rop.Result[Out] {
+			return f(r)
+		}, mode)
+}
+
+func TraverseWithCtx[In, Out any](ctx context.Context, items []In,
+	f func(ctx context.Context, r In) rop.Result[Out], mode TraverseMode) rop.Result[[]Out] {
+
+	values := make([]Out, 0, len(items))
+	var fails, cancels []error
+
+	for _, item := range items {
+
+		res := f(ctx, item)
+		switch {
+		case res.IsSuccess():
+			values = append(values, res.Result())
+			continue
+		case res.IsFiltered():
+			continue
+		case mode == FailFast:
+			return passOn[Out, []Out](res)
+		case res.IsCancel():
+			cancels = append(cancels, res.Err())
+		default:
+			fails = append(fails, res.Err())
+		}
+	}
+
+	if len(fails) > 0 {
+		return rop.Fail[[]Out](errors.Join(append(fails, cancels...)...))
+	}
+	if len(cancels) > 0 {
+		return rop.Cancel[[]Out](errors.Join(cancels...))
+	}
+	return rop.Success(values)
+}
+
+// Partition splits results into the successful values and the results that
+// failed or were cancelled. Filtered results are left out.
+func Partition[T any](inputs []rop.Result[T]) (successes []T, failures []rop.Result[T]) {
+
+	successes = make([]T, 0, len(inputs))
+	failures = make([]rop.Result[T], 0)
+
+	for _, in := range inputs {
+		switch {
+		case in.IsSuccess():
+			successes = append(successes, in.Result())
+		case !in.IsFiltered():
+			failures = append(failures, in)
+		}
+	}
+	return successes, failures
+}
+
+func passOn[In, Out any](input rop.Result[In]) rop.Result[Out] {
+	if input.IsCancel() {
+		return rop.Carry(input, rop.Cancel[Out](input.Err()))
+	} else {
+		return rop.Carry(input, rop.Fail[Out](input.Err()))
+	}
+}
@@ -0,0 +1,131 @@
+package rop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	StageNameKey    = "stage-name"
+	TrailEnabledKey = "trail-enabled"
+	TrailSeparator  = "→"
+)
+
+// StageError wraps an error produced by a named stage.
+type StageError struct {
+	Stage string
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Stage, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// WithStage names the stages that run with the returned context. Their
+// results get a "kind(name)" entry in the trail and their errors are
+// wrapped in *StageError.
+func WithStage(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, StageNameKey, name)
+}
+
+func GetStageFromCtx(ctx context.Context) (name string, ok bool) {
+	name, ok = ctx.Value(StageNameKey).(string)
+	return name, ok && name != ""
+}
+
+// WithTrail makes unnamed stages record their kind in the trail as well.
+func WithTrail(ctx context.Context) context.Context {
+	return context.WithValue(ctx, TrailEnabledKey, true)
+}
+
+func GetTrailFromCtx(ctx context.Context) []string {
+	o, _ := ctx.Value(ResultOfKey).(origin)
+	return append([]string(nil), o.trail...)
+}
+
+// Trail lists the stages the result passed through, oldest first.
+func (r Result[T]) Trail() []string {
+	return append([]string(nil), r.trail...)
+}
+
+// Breadcrumb renders the trail and the error of the result, e.g.
+// "validate→switch→try(charge): card declined".
+func (r Result[T]) Breadcrumb() string {
+	return Breadcrumb(r.trail, r.err)
+}
+
+func Breadcrumb(trail []string, err error) string {
+
+	crumb := strings.Join(trail, TrailSeparator)
+	if err == nil {
+		return crumb
+	}
+
+	for { // the trail already names the stage
+		stageErr, ok := err.(*StageError)
+		if !ok {
+			break
+		}
+		err = stageErr.Err
+	}
+
+	if crumb == "" {
+		return err.Error()
+	}
+	return crumb + ": " + err.Error()
+}
+
+// Carry returns to with the provenance of from: its trail, unless to has its
+// own already, its metadata under the entries set on to, its cancel reason
+// when both are cancelled and its filtered state. Use it whenever a stage builds
+// a new Result out of its input.
+func Carry[In, Out any](from Result[In], to Result[Out]) Result[Out] {
+	if len(to.trail) == 0 {
+		to.trail = from.trail
+	}
+	to.meta = mergeMeta(from.meta, to.meta)
+	if from.isFiltered && !to.isSuccess && !to.isCancel { // passed through as a failure
+		to.isFiltered = true
+	}
+	if to.isCancel && from.isCancel && to.reason == CancelUnspecified {
+		to.reason, to.cause = from.reason, from.cause
+	}
+	return to
+}
+
+// Step is Carry for a stage of the given kind that has actually processed
+// from. The stage is added to the trail when ctx names it or enables the
+// trail, once even if to was made by an inner step of the same stage, and an
+// error of a named stage is wrapped in *StageError.
+func Step[In, Out any](ctx context.Context, kind string, from Result[In], to Result[Out]) Result[Out] {
+
+	to = Carry(from, to)
+
+	name, named := GetStageFromCtx(ctx)
+	if !named && ctx.Value(TrailEnabledKey) != true {
+		return to
+	}
+
+	entry := kind
+	if named {
+		entry = kind + "(" + name + ")"
+	}
+
+	if n := len(to.trail); n <= len(from.trail) || to.trail[n-1] != entry {
+		to.trail = append(append(make([]string, 0, n+1), to.trail...), entry)
+	}
+
+	if named && !to.isSuccess && to.err != nil {
+		var stageErr *StageError
+		if !errors.As(to.err, &stageErr) || stageErr.Stage != name {
+			to.err = &StageError{Stage: name, Err: to.err}
+		}
+	}
+	return to
+}
@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// InMemory keeps the exported spans, e.g. to inspect the trace of a test
+// pipeline.
+type InMemory struct {
+	mu    sync.Mutex
+	spans []SpanData
+}
+
+func NewInMemory() *InMemory {
+	return &InMemory{}
+}
+
+func (m *InMemory) Export(span SpanData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.spans = append(m.spans, span)
+	return nil
+}
+
+// Spans returns the spans in the order they ended.
+func (m *InMemory) Spans() []SpanData {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]SpanData(nil), m.spans...)
+}
+
+func (m *InMemory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.spans = nil
+}
+
+// JSONExporter writes every span as a line of JSON.
+type JSONExporter struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+func NewJSONExporter(w io.Writer) *JSONExporter {
+	return &JSONExporter{enc: json.NewEncoder(w)}
+}
+
+// NewJSONFileExporter writes the spans to the file at path, truncating it.
+// Close the exporter after the last span has ended.
+func NewJSONFileExporter(path string) (*JSONExporter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONExporter{enc: json.NewEncoder(f), closer: f}, nil
+}
+
+func (e *JSONExporter) Export(span SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.enc.Encode(span)
+}
+
+// Close closes the file of NewJSONFileExporter, a writer passed to
+// NewJSONExporter is left open.
+func (e *JSONExporter) Close() error {
+	if e.closer == nil {
+		return nil
+	}
+	return e.closer.Close()
+}
+
+// Multi exports to all exporters and returns the first error.
+func Multi(exporters ...Exporter) Exporter {
+	return multi(exporters)
+}
+
+type multi []Exporter
+
+func (m multi) Export(span SpanData) error {
+	var first error
+	for _, e := range m {
+		if err := e.Export(span); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+)
+
+// SpanKey is the metadata key of the span of the last stage that processed
+// an item.
+var SpanKey = rop.NewKey[SpanContext]("tracing.span")
+
+// Interceptor opens a span for every user function call of the stages and
+// records the state and error of its result on it.
+//
+// The spans of an item are chained through its SpanKey metadata, so they
+// make up one trace across the goroutines of mass and bridge stages. When
+// ctx already carries a span, e.g. of a request, the spans are its children
+// instead and are linked to the previous span of the item.
+func Interceptor(tracer Tracer) rop.Interceptor {
+	return func(ctx context.Context, call rop.Call,
+		next func(ctx context.Context) rop.Outcome) rop.Outcome {
+
+		var links []SpanContext
+		if prev, ok := rop.GetOutcomeMeta(call.Input, SpanKey); ok && prev.IsValid() {
+			if SpanFromContext(ctx) == nil {
+				ctx = ContextWithSpanContext(ctx, prev)
+			} else {
+				links = append(links, prev)
+			}
+		}
+
+		name := call.Kind
+		if call.Stage != "" {
+			name = call.Kind + "(" + call.Stage + ")"
+		}
+
+		ctx, span := tracer.Start(ctx, name, links...)
+		defer span.End()
+
+		out := next(ctx)
+
+		state := stateOf(out)
+		span.SetAttributes(
+			Attr("rop.stage", call.Stage),
+			Attr("rop.kind", call.Kind),
+			Attr("rop.state", state))
+
+		switch state {
+		case "fail":
+			if out == nil {
+				span.SetStatus(Error, "nil outcome")
+				return out
+			}
+			span.RecordError(out.Err())
+			span.SetStatus(Error, errString(out.Err()))
+		case "cancel":
+			span.RecordError(out.Err())
+			span.SetStatus(Unset, "")
+		default:
+			span.SetStatus(Ok, "")
+		}
+
+		return rop.SetOutcomeMeta(out, SpanKey, span.SpanContext())
+	}
+}
+
+func stateOf(o rop.Outcome) string {
+	switch {
+	case o == nil:
+		return "fail"
+	case o.IsSuccess():
+		return "success"
+	case o.IsFiltered():
+		return "filtered"
+	case o.IsCancel():
+		return "cancel"
+	default:
+		return "fail"
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
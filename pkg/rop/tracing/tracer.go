@@ -0,0 +1,135 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SpanData is a finished span as exporters get it.
+type SpanData struct {
+	Name              string         `json:"name"`
+	TraceID           string         `json:"trace_id"`
+	SpanID            string         `json:"span_id"`
+	ParentID          string         `json:"parent_id,omitempty"`
+	Links             []string       `json:"links,omitempty"`
+	Start             time.Time      `json:"start"`
+	End               time.Time      `json:"end"`
+	Attributes        map[string]any `json:"attributes,omitempty"`
+	Status            string         `json:"status"`
+	StatusDescription string         `json:"status_description,omitempty"`
+	Errors            []string       `json:"errors,omitempty"`
+}
+
+// Exporter receives every span when it ends.
+type Exporter interface {
+	Export(span SpanData) error
+}
+
+// New returns a Tracer exporting the spans it records to exporter. Errors of
+// the exporter are handed to errF, if given.
+func New(exporter Exporter, errF func(err error)) Tracer {
+	return &tracer{exporter: exporter, errF: errF}
+}
+
+type tracer struct {
+	exporter Exporter
+	errF     func(err error)
+}
+
+func (t *tracer) Start(ctx context.Context, name string, links ...SpanContext) (context.Context, Span) {
+
+	parent := SpanContextFromContext(ctx)
+
+	traceID := parent.TraceID
+	if !parent.IsValid() {
+		traceID = newTraceID()
+	}
+
+	s := &span{
+		tracer: t,
+		sc:     SpanContext{TraceID: traceID, SpanID: newSpanID()},
+		data: SpanData{
+			Name:       name,
+			Start:      time.Now(),
+			Attributes: make(map[string]any),
+		},
+	}
+	s.data.TraceID = s.sc.TraceID.String()
+	s.data.SpanID = s.sc.SpanID.String()
+	if parent.IsValid() {
+		s.data.ParentID = parent.SpanID.String()
+	}
+	for _, link := range links {
+		if link.IsValid() {
+			s.data.Links = append(s.data.Links, link.TraceID.String()+"-"+link.SpanID.String())
+		}
+	}
+
+	return ContextWithSpan(ctx, s), s
+}
+
+type span struct {
+	tracer *tracer
+	sc     SpanContext
+
+	mu    sync.Mutex
+	data  SpanData
+	ended bool
+}
+
+func (s *span) SpanContext() SpanContext {
+	return s.sc
+}
+
+func (s *span) SetAttributes(attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range attrs {
+		s.data.Attributes[a.Key] = a.Value
+	}
+}
+
+func (s *span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Errors = append(s.data.Errors, err.Error())
+}
+
+func (s *span) SetStatus(code StatusCode, description string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Status = code.String()
+	if code == Error {
+		s.data.StatusDescription = description
+	} else {
+		s.data.StatusDescription = ""
+	}
+}
+
+// End exports the span; later calls are ignored.
+func (s *span) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	if s.data.Status == "" {
+		s.data.Status = Unset.String()
+	}
+	data := s.data
+	s.mu.Unlock()
+
+	if err := s.tracer.exporter.Export(data); err != nil && s.tracer.errF != nil {
+		s.tracer.errF(err)
+	}
+}
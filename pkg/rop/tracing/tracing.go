@@ -0,0 +1,142 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// The tracing API follows the shape of OpenTelemetry's trace package, so an
+// adapter to it only converts the ids, the attributes and the status codes.
+
+type TraceID [16]byte
+
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+type SpanID [8]byte
+
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanContext identifies a span within its trace.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+type StatusCode int
+
+const (
+	Unset StatusCode = iota
+	Error
+	Ok
+)
+
+func (c StatusCode) String() string {
+	switch c {
+	case Error:
+		return "error"
+	case Ok:
+		return "ok"
+	default:
+		return "unset"
+	}
+}
+
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+func Attr(key string, value any) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is an operation of a trace.
+type Span interface {
+	SpanContext() SpanContext
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	SetStatus(code StatusCode, description string)
+	End()
+}
+
+// Tracer starts spans. The new span is a child of the span in ctx, if any,
+// and is linked to links, e.g. the spans of the items it processes.
+type Tracer interface {
+	Start(ctx context.Context, name string, links ...SpanContext) (context.Context, Span)
+}
+
+type spanKey struct{}
+
+// ContextWithSpan makes span the parent of the spans started with the
+// returned context.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span of ctx, nil if there is none.
+func SpanFromContext(ctx context.Context) Span {
+	span, _ := ctx.Value(spanKey{}).(Span)
+	return span
+}
+
+// ContextWithSpanContext makes the span identified by sc the parent of the
+// spans started with the returned context, e.g. a span of another goroutine.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return ContextWithSpan(ctx, remoteSpan{sc: sc})
+}
+
+// SpanContextFromContext identifies the parent span of ctx.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.SpanContext()
+	}
+	return SpanContext{}
+}
+
+// remoteSpan stands for a span recorded elsewhere.
+type remoteSpan struct {
+	sc SpanContext
+}
+
+func (s remoteSpan) SpanContext() SpanContext {
+	return s.sc
+}
+
+func (s remoteSpan) SetAttributes(...Attribute) {
+}
+
+func (s remoteSpan) RecordError(error) {
+}
+
+func (s remoteSpan) SetStatus(StatusCode, string) {
+}
+
+func (s remoteSpan) End() {
+}
+
+func newTraceID() (id TraceID) {
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() (id SpanID) {
+	_, _ = rand.Read(id[:])
+	return id
+}
@@ -0,0 +1,27 @@
+package rop
+
+type Tuple2[T1, T2 any] struct {
+	V1 T1
+	V2 T2
+}
+
+type Tuple3[T1, T2, T3 any] struct {
+	V1 T1
+	V2 T2
+	V3 T3
+}
+
+type Tuple4[T1, T2, T3, T4 any] struct {
+	V1 T1
+	V2 T2
+	V3 T3
+	V4 T4
+}
+
+type Tuple5[T1, T2, T3, T4, T5 any] struct {
+	V1 T1
+	V2 T2
+	V3 T3
+	V4 T4
+	V5 T5
+}
@@ -6,7 +6,12 @@ import (
 	"fmt"
 	"github.com/ib-77/rop/pkg/rop"
 	"github.com/ib-77/rop/pkg/rop/bridge"
+	"github.com/ib-77/rop/pkg/rop/fan"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/ib-77/rop/test/clock"
+	"github.com/ib-77/rop/test/leak"
 	"github.com/stretchr/testify/assert"
+	"sync"
 	"testing"
 	"time"
 )
@@ -20,6 +25,8 @@ func TestValidate(t *testing.T) {
 	input2 <- 5
 	input2 <- 6
 	input2 <- 7
+	close(input1)
+	close(input2)
 	inputs <- input1
 	inputs <- input2
 
@@ -48,6 +55,8 @@ func TestValidateAndValidate(t *testing.T) {
 	input2 <- 5
 	input2 <- 6
 	input2 <- 7
+	close(input1)
+	close(input2)
 	inputs <- input1
 	inputs <- input2
 
@@ -78,6 +87,8 @@ func TestValidateWithErrAndValidate(t *testing.T) {
 	input2 <- 5
 	input2 <- 6
 	input2 <- 7
+	close(input1)
+	close(input2)
 	inputs <- input1
 	inputs <- input2
 
@@ -109,25 +120,30 @@ func TestValidateWithCancelAndValidate(t *testing.T) {
 	input2 <- 5
 	input2 <- 6
 	input2 <- 7
+	close(input1)
+	close(input2)
 	inputs <- input1
 	inputs <- input2
 
 	ctx, cancellation := context.WithTimeout(context.Background(), time.Second*3)
 	defer cancellation()
 
+	// the lanes are read one after the other and the second stage waits for
+	// the slow first one, so both have to wait however long it takes
+	wait := []mass.Option{mass.WithDrainTimeout(-1), mass.WithIdleTimeout(-1)}
 	outputs := bridge.AndValidate(ctx,
-		bridge.Validate(ctx, inputs, validateLongRunning, cancel, "err"),
-		validateT, cancelT, "and err")
+		bridge.Validate(ctx, inputs, validateLongRunning, cancel, "err", wait...),
+		validateT, cancelT, "and err", wait...)
 
 	assert.NotEmpty(t, outputs)
 	output1 := <-outputs
 	assert.Equal(t, rop.Success(1), <-output1)
-	assert.Equal(t, rop.Cancel[int](errors.New("and operation was cancelled 2")), <-output1)
+	assert.Equal(t, rop.CancelBecause[int](errors.New("and operation was cancelled 2"), context.DeadlineExceeded), <-output1)
 
 	output2 := <-outputs
 	assert.Equal(t, rop.Success(5), <-output2)
-	assert.Equal(t, rop.Cancel[int](errors.New("and operation was cancelled 6")), <-output2)
-	assert.Equal(t, rop.Cancel[int](errors.New("operation was cancelled 7")), <-output2)
+	assert.Equal(t, rop.CancelBecause[int](errors.New("and operation was cancelled 6"), context.DeadlineExceeded), <-output2)
+	assert.Equal(t, rop.CancelBecause[int](errors.New("operation was cancelled 7"), context.DeadlineExceeded), <-output2)
 }
 
 func TestValidateAndValidateAndFinallySuccess(t *testing.T) {
@@ -139,6 +155,8 @@ func TestValidateAndValidateAndFinallySuccess(t *testing.T) {
 	input2 <- 5
 	input2 <- 6
 	input2 <- 7
+	close(input1)
+	close(input2)
 	inputs <- input1
 	inputs <- input2
 
@@ -214,3 +232,223 @@ func failFinally(ctx context.Context, err error) string {
 func cancelFinally(ctx context.Context, r rop.Result[int]) string {
 	return fmt.Sprintf("cancel %v", r)
 }
+
+func TestLanesExitWithoutReader(t *testing.T) {
+	inputs := make(chan chan int, 2)
+	input1 := make(chan int, 3)
+	input1 <- 1
+	input1 <- 2
+	input1 <- 3
+	input2 := make(chan int, 1)
+	input2 <- 5
+	inputs <- input1
+	inputs <- input2
+
+	ctx, cancellation := context.WithCancel(context.Background())
+
+	outputs := bridge.Finally(ctx,
+		bridge.Map(ctx,
+			bridge.Validate(ctx, inputs, validateNoDelay, cancel, "err"),
+			func(_ context.Context, in int) int { return in }, cancelT),
+		successFinally, failFinally, cancelFinally)
+
+	output1 := <-outputs
+	assert.Equal(t, "success 1", <-output1)
+
+	cancellation() // and never read again
+	close(input1)
+	close(input2)
+
+	leak.VerifyNone(t)
+}
+
+func TestMapWithDropRemainingPolicy(t *testing.T) {
+	inputs := make(chan chan rop.Result[int], 2)
+	input1 := make(chan rop.Result[int], 2)
+	input2 := make(chan rop.Result[int], 1)
+	inputs <- input1
+	inputs <- input2
+
+	ctx, cancellation := context.WithCancel(context.Background())
+
+	outputs := bridge.Map(ctx, inputs,
+		func(_ context.Context, in int) int { return in }, cancelT,
+		mass.WithCancelPolicy(mass.DropRemaining))
+
+	for len(inputs) > 0 { // wait for both lanes to start
+		time.Sleep(time.Millisecond)
+	}
+	cancellation()
+
+	input1 <- rop.Success(1)
+	input1 <- rop.Success(2)
+	input2 <- rop.Success(5)
+	close(input1)
+	close(input2)
+
+	for output := range outputs {
+		for range output {
+			assert.Fail(t, "cancelled items must be dropped")
+		}
+	}
+}
+
+func TestRateLimitSharedAcrossLanes(t *testing.T) {
+	inputs := make(chan chan rop.Result[int], 2)
+	input1 := make(chan rop.Result[int], 2)
+	input1 <- rop.Success(1)
+	input1 <- rop.Success(2)
+	input2 := make(chan rop.Result[int], 2)
+	input2 <- rop.Success(5)
+	input2 <- rop.Success(6)
+	close(input1)
+	close(input2)
+	inputs <- input1
+	inputs <- input2
+
+	c := clock.NewManual()
+	limiter := rop.NewLimiter(1, 1, c)
+	ctx := context.Background()
+
+	outputs := bridge.Map(ctx, inputs,
+		func(_ context.Context, in int) int { return in }, cancelT,
+		mass.WithRateLimit(limiter))
+
+	merged := make(chan rop.Result[int])
+	var wg sync.WaitGroup
+	for _, output := range fan.ChsToSlice(outputs, 2) {
+		wg.Add(1)
+		go func(output chan rop.Result[int]) {
+			defer wg.Done()
+			for out := range output {
+				merged <- out
+			}
+		}(output)
+	}
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+
+	for i := 0; i < 4; i++ {
+		if i > 0 {
+			select {
+			case <-merged:
+				assert.Fail(t, "lanes must share one token per second")
+			case <-time.After(20 * time.Millisecond):
+			}
+			c.Advance(time.Second)
+		}
+		assert.True(t, (<-merged).IsSuccess())
+	}
+
+	_, ok := <-merged
+	assert.False(t, ok)
+}
+
+func TestTryRecoversPanic(t *testing.T) {
+	inputs := make(chan chan rop.Result[int], 1)
+	input := make(chan rop.Result[int], 2)
+	input <- rop.Success(0)
+	input <- rop.Success(1)
+	close(input)
+	inputs <- input
+
+	ctx := context.Background()
+	outputs := bridge.Try(ctx, inputs,
+		func(_ context.Context, in int) (int, error) {
+			return 10 / in, nil
+		}, cancelT)
+
+	output := <-outputs
+	failed := <-output
+	var panicErr *rop.PanicError
+	assert.ErrorAs(t, failed.Err(), &panicErr)
+	assert.Equal(t, rop.Success(10), <-output)
+}
+
+func TestMapKeepsMeta(t *testing.T) {
+	key := rop.NewKey[string]("tenant")
+
+	inputs := make(chan chan rop.Result[int], 1)
+	input := make(chan rop.Result[int], 1)
+	input <- rop.SetMeta(rop.Success(1), key, "acme")
+	close(input)
+	inputs <- input
+
+	ctx := context.Background()
+	outputs := bridge.Map(ctx, inputs,
+		func(_ context.Context, in int) string { return fmt.Sprint(in) }, cancelT)
+
+	output := <-<-outputs
+	tenant, ok := rop.GetMeta(output, key)
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tenant)
+	assert.Equal(t, "1", output.Result())
+}
+
+func TestLift(t *testing.T) {
+	inputs := make(chan chan rop.Result[int], 2)
+	input1 := make(chan rop.Result[int], 1)
+	input1 <- rop.Success(2)
+	input2 := make(chan rop.Result[int], 1)
+	input2 <- rop.Fail[int](errors.New("err"))
+	close(input1)
+	close(input2)
+	inputs <- input1
+	inputs <- input2
+
+	double := rop.NewOperator(
+		func(_ context.Context, in rop.Result[int]) rop.Result[int] {
+			if !in.IsSuccess() {
+				return in
+			}
+			return rop.Success(in.Result() * 2)
+		},
+		func(_ context.Context, in rop.Result[int]) rop.Result[int] {
+			return rop.Cancel[int](errors.New("cancelled"))
+		})
+
+	outputs := bridge.Lift(context.Background(), inputs, double)
+
+	output1 := <-outputs
+	output2 := <-outputs
+	assert.Equal(t, rop.Success(4), <-output1)
+	assert.Equal(t, rop.Fail[int](errors.New("err")), <-output2)
+}
+
+func TestInterceptors(t *testing.T) {
+	inputs := make(chan chan rop.Result[int], 2)
+	for i := 0; i < 2; i++ {
+		input := make(chan rop.Result[int], 2)
+		input <- rop.Success(1)
+		input <- rop.Success(2)
+		close(input)
+		inputs <- input
+	}
+
+	var mu sync.Mutex
+	kinds := make(map[string]int)
+	ctx := rop.WithInterceptors(context.Background(),
+		func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+			mu.Lock()
+			kinds[call.Kind]++
+			mu.Unlock()
+			return next(ctx)
+		})
+
+	outputs := bridge.Map(ctx, inputs, func(_ context.Context, in int) int { return in }, cancelT)
+
+	var wg sync.WaitGroup
+	for _, output := range fan.ChsToSlice(outputs, 2) {
+		wg.Add(1)
+		go func(output chan rop.Result[int]) {
+			defer wg.Done()
+			for range output {
+			}
+		}(output)
+	}
+	wg.Wait()
+
+	assert.Equal(t, map[string]int{"map": 4}, kinds)
+}
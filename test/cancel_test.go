@@ -0,0 +1,70 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type breakerOpen struct{}
+
+func (breakerOpen) Error() string { return "breaker open" }
+
+func (breakerOpen) CancelReason() rop.CancelReason { return rop.CancelBreaker }
+
+func Test_ReasonOf(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, rop.CancelUnspecified, rop.ReasonOf(nil))
+	assert.Equal(t, rop.CancelAborted, rop.ReasonOf(context.Canceled))
+	assert.Equal(t, rop.CancelAborted, rop.ReasonOf(errors.New("user abort")))
+	assert.Equal(t, rop.CancelDeadline, rop.ReasonOf(context.DeadlineExceeded))
+	assert.Equal(t, rop.CancelShutdown, rop.ReasonOf(fmt.Errorf("sigterm: %w", rop.ErrShutdown)))
+	assert.Equal(t, rop.CancelBreaker, rop.ReasonOf(breakerOpen{}))
+	assert.Equal(t, "breaker", rop.CancelBreaker.String())
+}
+
+func Test_CancelFromCtx(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, rop.Cancel[int](errors.New("stop")),
+		rop.CancelFromCtx[int](context.Background(), errors.New("stop")))
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(rop.ErrShutdown)
+
+	result := rop.CancelFromCtx[int](ctx, errors.New("stop"))
+	assert.True(t, result.IsCancel())
+	assert.True(t, result.IsShutdown())
+	assert.False(t, result.IsDeadline())
+	assert.Equal(t, rop.CancelShutdown, result.CancelReason())
+	assert.Equal(t, rop.ErrShutdown, result.CancelCause())
+	assert.Equal(t, "stop", result.Err().Error())
+}
+
+func Test_CancelReason_Carried(t *testing.T) {
+	t.Parallel()
+
+	cancelled := rop.CancelBecause[int](errors.New("stop"), context.DeadlineExceeded)
+
+	passed := rop.Carry(cancelled, rop.Cancel[string](cancelled.Err()))
+	assert.True(t, passed.IsDeadline())
+
+	recovered := rop.Carry(cancelled, rop.Success("fallback"))
+	assert.Equal(t, rop.CancelUnspecified, recovered.CancelReason())
+}
+
+func Test_GetCancelReasonFromCtx(t *testing.T) {
+	t.Parallel()
+
+	cancelled := rop.CancelBecause[int](errors.New("stop"), context.DeadlineExceeded)
+	assert.True(t, rop.IsDeadline(rop.WithResultOf(context.Background(), cancelled)))
+	assert.Equal(t, rop.CancelUnspecified, rop.GetCancelReasonFromCtx(context.Background()))
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(rop.ErrShutdown)
+	assert.True(t, rop.IsShutdown(ctx))
+}
@@ -17,6 +17,7 @@ func Test_OutNext(t *testing.T) {
 	inputCh <- rop.Success(4)
 	inputCh <- rop.Success(2)
 	inputCh <- rop.Success(5)
+	close(inputCh)
 
 	ctx := context.Background()
 	outputChs := fan.OutNext[int](ctx, inputCh, 4)
@@ -60,6 +61,7 @@ func Test_OutNext_SliceToChs(t *testing.T) {
 	inputCh <- rop.Success(4)
 	inputCh <- rop.Success(2)
 	inputCh <- rop.Success(5)
+	close(inputCh)
 
 	ctx := context.Background()
 	outputChs := fan.SliceToChs(fan.OutNext[int](ctx, inputCh, 4))
@@ -96,3 +98,38 @@ func Test_OutNext_SliceToChs(t *testing.T) {
 	wg.Wait()
 
 }
+
+func Test_OutNext_StopsOnCancel(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	inputCh := make(chan rop.Result[int])
+	outputChs := fan.OutNext[int](ctx, inputCh, 2)
+
+	inputCh <- rop.Success(1)
+	assert.Equal(t, rop.Success(1), <-outputChs[0])
+
+	inputCh <- rop.Success(2) // and never read outputChs[1]
+	cancel()
+
+	for _, ch := range outputChs {
+		for range ch {
+		}
+	}
+}
+
+func Test_OutRand_StopsOnClosedInput(t *testing.T) {
+
+	inputCh := make(chan rop.Result[int], 3)
+	inputCh <- rop.Success(1)
+	inputCh <- rop.Success(2)
+	inputCh <- rop.Success(3)
+	close(inputCh)
+
+	outputChs := fan.OutRand[int](context.Background(), inputCh, 2)
+
+	sum := 0
+	for value := range fan.InTee(context.Background(), fan.SliceToChs(outputChs)) {
+		sum += value.Result()
+	}
+	assert.Equal(t, 6, sum)
+}
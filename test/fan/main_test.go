@@ -0,0 +1,10 @@
+package fan
+
+import (
+	"github.com/ib-77/rop/test/leak"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	leak.VerifyTestMain(m)
+}
@@ -0,0 +1,100 @@
+package group
+
+import (
+	"context"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/group"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func routes() []group.Branch[int] {
+	return []group.Branch[int]{
+		group.NewBranch("small", func(ctx context.Context, in rop.Result[int]) (bool, rop.Result[int]) {
+			return in.Result() < 10, rop.Success(in.Result() * 2)
+		}),
+		group.NewBranch("fraud", func(ctx context.Context, in rop.Result[int]) (bool, rop.Result[int]) {
+			return in.Result() == 13, rop.Fail[int](errFraud)
+		}),
+		group.NewBranch("large", func(ctx context.Context, in rop.Result[int]) (bool, rop.Result[int]) {
+			return in.Result() >= 10 && in.Result() < 100, rop.Success(in.Result() / 2)
+		}),
+	}
+}
+
+func Test_OrTeeBranches(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input    int
+		expected rop.Result[int]
+		decision group.Decision
+	}{
+		{1, rop.Success(2), group.Decision{Label: "small", Accepted: true}},
+		{13, rop.Fail[int](errFraud), group.Decision{Label: "fraud", Accepted: true}},
+		{20, rop.Success(10), group.Decision{Label: "large", Accepted: true}},
+		{500, rop.Success(500), group.Decision{Label: group.NoneAccepted}},
+	}
+
+	for _, c := range cases {
+		res := group.OrTeeBranches(context.Background(), rop.Success(c.input), routes()...)
+
+		assert.Equal(t, c.expected.IsSuccess(), res.IsSuccess())
+		assert.Equal(t, c.expected.Result(), res.Result())
+		assert.Equal(t, c.expected.Err(), res.Err())
+
+		d, ok := group.DecisionOf(res)
+		assert.True(t, ok)
+		assert.Equal(t, c.decision, d)
+	}
+}
+
+func Test_OrTeeBranches_KeepsInputMeta(t *testing.T) {
+	t.Parallel()
+
+	key := rop.NewKey[string]("tenant")
+	res := group.OrTeeBranches(context.Background(), rop.SetMeta(rop.Success(1), key, "acme"), routes()...)
+
+	tenant, _ := rop.GetMeta(res, key)
+	assert.Equal(t, "acme", tenant)
+}
+
+func Test_OrTeeBranches_NotSuccess(t *testing.T) {
+	t.Parallel()
+
+	res := group.OrTeeBranches(context.Background(), rop.Fail[int](errLimits), routes()...)
+
+	assert.Equal(t, rop.Fail[int](errLimits), res)
+	_, ok := group.DecisionOf(res)
+	assert.False(t, ok)
+}
+
+func Test_OrSwitchBranches(t *testing.T) {
+	t.Parallel()
+
+	var seen group.Decision
+	switchF := func(ctx context.Context, r rop.Result[int]) rop.Result[string] {
+		seen, _ = group.DecisionOf(r)
+		if !r.IsSuccess() {
+			return rop.Fail[string](r.Err())
+		}
+		return rop.Success(fmt.Sprint(r.Result()))
+	}
+
+	res := group.OrSwitchBranches(context.Background(), rop.Success(20), switchF, routes()...)
+	assert.Equal(t, "10", res.Result())
+	assert.Equal(t, group.Decision{Label: "large", Accepted: true}, seen)
+	d, _ := group.DecisionOf(res)
+	assert.Equal(t, seen, d)
+
+	res = group.OrSwitchBranches(context.Background(), rop.Success(500), switchF, routes()...)
+	assert.Equal(t, "500", res.Result())
+	d, _ = group.DecisionOf(res)
+	assert.Equal(t, group.Decision{Label: group.NoneAccepted}, d)
+
+	res = group.OrSwitchBranches(context.Background(), rop.Success(13), switchF, routes()...)
+	assert.ErrorIs(t, res.Err(), errFraud)
+	d, _ = group.DecisionOf(res)
+	assert.Equal(t, group.Decision{Label: "fraud", Accepted: true}, d)
+}
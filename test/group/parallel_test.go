@@ -0,0 +1,208 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/group"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var (
+	errFraud  = errors.New("fraud suspected")
+	errLimits = errors.New("limit exceeded")
+)
+
+type check = func(ctx context.Context, in rop.Result[int]) rop.Result[int]
+
+func passAfter(d time.Duration) check {
+	return func(ctx context.Context, in rop.Result[int]) rop.Result[int] {
+		select {
+		case <-time.After(d):
+			return in
+		case <-ctx.Done():
+			return rop.Cancel[int](context.Cause(ctx))
+		}
+	}
+}
+
+func failAfter(d time.Duration, err error) check {
+	return func(ctx context.Context, in rop.Result[int]) rop.Result[int] {
+		select {
+		case <-time.After(d):
+			return rop.Fail[int](err)
+		case <-ctx.Done():
+			return rop.Cancel[int](context.Cause(ctx))
+		}
+	}
+}
+
+func Test_AndTeeParallel_RunsConcurrently(t *testing.T) {
+	t.Parallel()
+
+	started := time.Now()
+	result := group.AndTeeParallel(context.Background(), rop.Success(100), group.Parallel{},
+		passAfter(50*time.Millisecond), passAfter(50*time.Millisecond), passAfter(50*time.Millisecond))
+
+	assert.Equal(t, rop.Success(100), result)
+	assert.Less(t, time.Since(started), 140*time.Millisecond)
+}
+
+func Test_AndTeeParallel_Limit(t *testing.T) {
+	t.Parallel()
+
+	var running, peak atomic.Int32
+	tracked := func(ctx context.Context, in rop.Result[int]) rop.Result[int] {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		return in
+	}
+
+	result := group.AndTeeParallel(context.Background(), rop.Success(1), group.Parallel{Limit: 2},
+		tracked, tracked, tracked, tracked, tracked)
+
+	assert.True(t, result.IsSuccess())
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func Test_AndTeeParallel_FailFastCancelsSiblings(t *testing.T) {
+	t.Parallel()
+
+	var cause atomic.Value
+	slow := func(ctx context.Context, in rop.Result[int]) rop.Result[int] {
+		<-ctx.Done()
+		cause.Store(context.Cause(ctx))
+		return rop.Cancel[int](ctx.Err())
+	}
+
+	started := time.Now()
+	result := group.AndTeeParallel(context.Background(), rop.Success(1), group.Parallel{},
+		slow, failAfter(10*time.Millisecond, errFraud), slow)
+
+	assert.Equal(t, rop.Fail[int](errFraud), result)
+	assert.Equal(t, group.ErrSiblingFailed, cause.Load())
+	assert.Less(t, time.Since(started), time.Second)
+}
+
+func Test_AndTeeParallel_FailFastSkipsPending(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	counted := func(ctx context.Context, in rop.Result[int]) rop.Result[int] {
+		calls.Add(1)
+		return in
+	}
+
+	result := group.AndTeeParallel(context.Background(), rop.Success(1), group.Parallel{Limit: 1},
+		failAfter(0, errFraud), counted, counted)
+
+	assert.Equal(t, rop.Fail[int](errFraud), result)
+	assert.Zero(t, calls.Load())
+}
+
+func Test_AndTeeParallel_CollectAll(t *testing.T) {
+	t.Parallel()
+
+	result := group.AndTeeParallel(context.Background(), rop.Success(1), group.Parallel{CollectAll: true},
+		failAfter(20*time.Millisecond, errFraud),
+		passAfter(0),
+		failAfter(0, errLimits))
+
+	assert.False(t, result.IsSuccess())
+	assert.False(t, result.IsCancel())
+	assert.ErrorIs(t, result.Err(), errFraud)
+	assert.ErrorIs(t, result.Err(), errLimits)
+	assert.Equal(t, "fraud suspected\nlimit exceeded", result.Err().Error())
+}
+
+func Test_AndTeeParallel_RecoversPanic(t *testing.T) {
+	t.Parallel()
+
+	result := group.AndTeeParallel(context.Background(), rop.Success(1), group.Parallel{},
+		func(ctx context.Context, in rop.Result[int]) rop.Result[int] {
+			panic("boom")
+		})
+
+	var panicErr *rop.PanicError
+	assert.ErrorAs(t, result.Err(), &panicErr)
+}
+
+func Test_AndTeeParallel_ParentCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(rop.ErrShutdown)
+
+	result := group.AndTeeParallel(ctx, rop.Success(1), group.Parallel{Limit: 1}, passAfter(time.Hour))
+
+	assert.True(t, result.IsCancel())
+}
+
+func Test_AndSwitchParallel(t *testing.T) {
+	t.Parallel()
+
+	toStr := func(ctx context.Context, r rop.Result[int]) rop.Result[string] {
+		if !r.IsSuccess() {
+			return rop.Fail[string](r.Err())
+		}
+		return rop.Success(strconv.Itoa(r.Result()))
+	}
+
+	ok := group.AndSwitchParallel(context.Background(), rop.Success(7), toStr, group.Parallel{},
+		passAfter(0), passAfter(0))
+	assert.Equal(t, rop.Success("7"), ok)
+
+	failed := group.AndSwitchParallel(context.Background(), rop.Success(7), toStr, group.Parallel{},
+		passAfter(0), failAfter(0, errLimits))
+	assert.Equal(t, rop.Fail[string](errLimits), failed)
+}
+
+func Test_OrSwitchParallel_FirstAcceptedInOrder(t *testing.T) {
+	t.Parallel()
+
+	alt := func(d time.Duration, accept bool, res rop.Result[int]) func(ctx context.Context,
+		in rop.Result[int]) (bool, rop.Result[int]) {
+
+		return func(ctx context.Context, in rop.Result[int]) (bool, rop.Result[int]) {
+			time.Sleep(d)
+			return accept, res
+		}
+	}
+	switchF := func(ctx context.Context, r rop.Result[int]) rop.Result[string] {
+		if !r.IsSuccess() {
+			return rop.Fail[string](r.Err())
+		}
+		return rop.Success(strconv.Itoa(r.Result()))
+	}
+
+	started := time.Now()
+	result := group.OrSwitchParallel(context.Background(), rop.Success(1), switchF, group.Parallel{},
+		alt(40*time.Millisecond, false, rop.Success(0)),
+		alt(40*time.Millisecond, true, rop.Success(2)),
+		alt(0, true, rop.Success(3)))
+
+	assert.Equal(t, rop.Success("2"), result)
+	assert.Less(t, time.Since(started), 75*time.Millisecond)
+
+	result = group.OrSwitchParallel(context.Background(), rop.Success(1), switchF, group.Parallel{},
+		alt(0, false, rop.Success(0)),
+		func(ctx context.Context, in rop.Result[int]) (bool, rop.Result[int]) { panic("boom") })
+
+	var panicErr *rop.PanicError
+	assert.ErrorAs(t, result.Err(), &panicErr)
+
+	result = group.OrSwitchParallel(context.Background(), rop.Success(7), switchF, group.Parallel{Limit: 1},
+		alt(0, false, rop.Success(0)))
+	assert.Equal(t, rop.Success("7"), result)
+}
@@ -0,0 +1,225 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/group"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type replica = func(ctx context.Context, r int) rop.Result[string]
+
+func answerAfter(d time.Duration, name string) replica {
+	return func(ctx context.Context, r int) rop.Result[string] {
+		select {
+		case <-time.After(d):
+			return rop.Success(fmt.Sprintf("%s:%d", name, r))
+		case <-ctx.Done():
+			return rop.Cancel[string](context.Cause(ctx))
+		}
+	}
+}
+
+func failWith(err error) replica {
+	return func(ctx context.Context, r int) rop.Result[string] {
+		return rop.Fail[string](err)
+	}
+}
+
+func Test_Race_FirstSuccessWins(t *testing.T) {
+	t.Parallel()
+
+	var loserCause atomic.Value
+	loser := func(ctx context.Context, r int) rop.Result[string] {
+		<-ctx.Done()
+		loserCause.Store(context.Cause(ctx))
+		return rop.Cancel[string](ctx.Err())
+	}
+
+	result := group.Race(context.Background(), rop.Success(1),
+		loser, answerAfter(10*time.Millisecond, "b"), failWith(errFraud))
+
+	assert.Equal(t, rop.Success("b:1"), result)
+	assert.Eventually(t, func() bool {
+		return loserCause.Load() == group.ErrNotNeeded
+	}, time.Second, time.Millisecond)
+}
+
+func Test_Race_NoneSucceeds(t *testing.T) {
+	t.Parallel()
+
+	result := group.Race(context.Background(), rop.Success(1), failWith(errFraud), failWith(errLimits))
+
+	assert.False(t, result.IsSuccess())
+	assert.ErrorIs(t, result.Err(), errFraud)
+	assert.ErrorIs(t, result.Err(), errLimits)
+}
+
+func Test_Race_PassesInputThrough(t *testing.T) {
+	t.Parallel()
+
+	cancelled := group.Race(context.Background(), rop.Cancel[int](errors.New("stop")), failWith(errFraud))
+	assert.Equal(t, rop.Cancel[string](errors.New("stop")), cancelled)
+
+	none := group.Race[int, string](context.Background(), rop.Success(1))
+	assert.ErrorIs(t, none.Err(), group.ErrNoAlternatives)
+}
+
+func Test_Hedged_StartsBackupAfterDelay(t *testing.T) {
+	t.Parallel()
+
+	var started atomic.Int32
+	counted := func(f replica) replica {
+		return func(ctx context.Context, r int) rop.Result[string] {
+			started.Add(1)
+			return f(ctx, r)
+		}
+	}
+
+	result := group.Hedged(context.Background(), rop.Success(1), 20*time.Millisecond,
+		counted(answerAfter(time.Hour, "slow")),
+		counted(answerAfter(0, "backup")),
+		counted(answerAfter(0, "unused")))
+
+	assert.Equal(t, rop.Success("backup:1"), result)
+	assert.Equal(t, int32(2), started.Load())
+}
+
+func Test_Hedged_FastAnswerNeedsNoBackup(t *testing.T) {
+	t.Parallel()
+
+	var backups atomic.Int32
+	result := group.Hedged(context.Background(), rop.Success(1), time.Second,
+		answerAfter(0, "primary"),
+		func(ctx context.Context, r int) rop.Result[string] {
+			backups.Add(1)
+			return rop.Success("backup")
+		})
+
+	assert.Equal(t, rop.Success("primary:1"), result)
+	assert.Zero(t, backups.Load())
+}
+
+func Test_Hedged_FailureStartsNextAtOnce(t *testing.T) {
+	t.Parallel()
+
+	started := time.Now()
+	result := group.Hedged(context.Background(), rop.Success(1), time.Hour,
+		failWith(errFraud), answerAfter(0, "backup"))
+
+	assert.Equal(t, rop.Success("backup:1"), result)
+	assert.Less(t, time.Since(started), time.Second)
+}
+
+func Test_Hedged_ParentCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	result := group.Hedged(ctx, rop.Success(1), time.Hour, answerAfter(time.Hour, "slow"))
+
+	assert.True(t, result.IsCancel())
+	assert.True(t, result.IsDeadline())
+}
+
+func Test_Quorum(t *testing.T) {
+	t.Parallel()
+
+	var loserCause atomic.Value
+	slow := func(ctx context.Context, r int) rop.Result[string] {
+		<-ctx.Done()
+		loserCause.Store(context.Cause(ctx))
+		return rop.Cancel[string](ctx.Err())
+	}
+
+	result := group.Quorum(context.Background(), rop.Success(1), 2,
+		answerAfter(10*time.Millisecond, "a"), slow, failWith(errFraud), answerAfter(0, "d"))
+
+	assert.Equal(t, rop.Success([]string{"a:1", "d:1"}), result)
+	assert.Eventually(t, func() bool {
+		return loserCause.Load() == group.ErrNotNeeded
+	}, time.Second, time.Millisecond)
+}
+
+func Test_Quorum_OutOfReach(t *testing.T) {
+	t.Parallel()
+
+	started := time.Now()
+	result := group.Quorum(context.Background(), rop.Success(1), 2,
+		answerAfter(time.Hour, "a"), failWith(errFraud), failWith(errLimits))
+
+	assert.ErrorIs(t, result.Err(), group.ErrNoQuorum)
+	assert.ErrorIs(t, result.Err(), errFraud)
+	assert.ErrorIs(t, result.Err(), errLimits)
+	assert.Contains(t, result.Err().Error(), "quorum not reached: 0 of 3 succeeded, 2 needed: ")
+	assert.Less(t, time.Since(started), time.Second)
+}
+
+func Test_Quorum_MoreThanAlternatives(t *testing.T) {
+	t.Parallel()
+
+	result := group.Quorum(context.Background(), rop.Success(1), 3, answerAfter(0, "a"))
+	assert.ErrorIs(t, result.Err(), group.ErrNoQuorum)
+
+	none := group.Quorum[int, string](context.Background(), rop.Success(1), 0)
+	assert.Equal(t, rop.Success([]string{}), none)
+}
+
+func Test_GroupStages_Named(t *testing.T) {
+	t.Parallel()
+
+	ctx := rop.WithStage(context.Background(), "replicas")
+
+	race := group.Race(ctx, rop.Success(1), failWith(errors.New("down")))
+	assert.Equal(t, []string{"race(replicas)"}, race.Trail())
+	assert.Equal(t, "replicas: down", race.Err().Error())
+
+	quorum := group.Quorum(ctx, rop.Success(1), 1, answerAfter(0, "a"))
+	assert.Equal(t, []string{"quorum(replicas)"}, quorum.Trail())
+
+	checks := group.AndTeeWithCtx(rop.WithStage(context.Background(), "checks"), rop.Success(1),
+		func(ctx context.Context, in rop.Result[int]) rop.Result[int] {
+			return rop.Fail[int](errors.New("kyc"))
+		})
+	var stageErr *rop.StageError
+	assert.ErrorAs(t, checks.Err(), &stageErr)
+	assert.Equal(t, "checks", stageErr.Stage)
+	assert.Equal(t, []string{"andtee(checks)"}, checks.Trail())
+
+	routed := group.OrSwitchWithCtx(rop.WithTrail(context.Background()), rop.Success(1),
+		func(ctx context.Context, r rop.Result[int]) rop.Result[string] {
+			return rop.Carry(r, rop.Success("none"))
+		},
+		func(ctx context.Context, in rop.Result[int]) (bool, rop.Result[int]) {
+			return false, in
+		})
+	assert.Equal(t, []string{"orswitch"}, routed.Trail())
+}
+
+func Test_GroupMembers_Intercepted(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ctx := rop.WithInterceptors(context.Background(),
+		func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+			if call.Kind == "group" {
+				calls.Add(1)
+			}
+			return next(ctx)
+		})
+
+	group.AndTeeWithCtx(ctx, rop.Success(1), passAfter(0), passAfter(0))
+	group.AndTeeParallel(ctx, rop.Success(1), group.Parallel{}, passAfter(0), passAfter(0))
+	group.OrTeeBranches(ctx, rop.Success(20), routes()...)
+	group.Race(ctx, rop.Success(1), answerAfter(0, "a"), answerAfter(0, "b"))
+	group.Quorum(ctx, rop.Success(1), 2, answerAfter(0, "a"), answerAfter(0, "b"))
+
+	// OrTeeBranches stops at the accepting branch
+	assert.Equal(t, int32(2+2+3+2+2), calls.Load())
+}
@@ -0,0 +1,99 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type ctxMark struct{}
+
+func recording(name string, log *[]string) rop.Interceptor {
+	return func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+		*log = append(*log, name+">")
+		out := next(context.WithValue(ctx, ctxMark{}, name))
+		*log = append(*log, "<"+name)
+		return out
+	}
+}
+
+func Test_Intercept_Order(t *testing.T) {
+	t.Parallel()
+
+	var log []string
+	ctx := rop.WithInterceptors(context.Background(), recording("outer", &log))
+	ctx = rop.WithInterceptors(ctx, recording("inner", &log))
+
+	result := rop.Intercept(ctx, "map", rop.Success(1), func(ctx context.Context) rop.Result[int] {
+		log = append(log, "call:"+ctx.Value(ctxMark{}).(string))
+		return rop.Success(2)
+	})
+
+	assert.Equal(t, rop.Success(2), result)
+	assert.Equal(t, []string{"outer>", "inner>", "call:inner", "<inner", "<outer"}, log)
+}
+
+func Test_Intercept_SeesCall(t *testing.T) {
+	t.Parallel()
+
+	var seen rop.Call
+	var output rop.Outcome
+	ctx := rop.WithInterceptors(rop.WithStage(context.Background(), "charge"),
+		func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+			seen = call
+			output = next(ctx)
+			return output
+		})
+
+	result := rop.Invoke(ctx, "try", rop.Success(1), func(ctx context.Context) rop.Result[string] {
+		return rop.Fail[string](errors.New("declined"))
+	})
+
+	assert.Equal(t, "charge", seen.Stage)
+	assert.Equal(t, "try", seen.Kind)
+	assert.Equal(t, rop.Success(1), seen.Input)
+	assert.Equal(t, rop.Fail[string](errors.New("declined")), output)
+	assert.EqualError(t, result.Err(), "charge: declined")
+	assert.Equal(t, []string{"try(charge)"}, result.Trail())
+}
+
+func Test_Intercept_ShortCircuit(t *testing.T) {
+	t.Parallel()
+
+	errDenied := errors.New("denied")
+	deny := func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+		return rop.Fail[any](errDenied)
+	}
+	called := false
+	f := func(ctx context.Context) rop.Result[int] {
+		called = true
+		return rop.Success(1)
+	}
+
+	result := rop.Intercept(rop.WithInterceptors(context.Background(), deny), "map", rop.Success(0), f)
+
+	assert.False(t, called)
+	assert.Equal(t, rop.Fail[int](errDenied), result)
+}
+
+func Test_Intercept_UnusableOutcome(t *testing.T) {
+	t.Parallel()
+
+	foreign := func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+		next(ctx)
+		return rop.Success("not an int")
+	}
+	none := func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+		return nil
+	}
+	f := func(ctx context.Context) rop.Result[int] {
+		return rop.Success(1)
+	}
+
+	for _, interceptor := range []rop.Interceptor{foreign, none} {
+		result := rop.Intercept(rop.WithInterceptors(context.Background(), interceptor), "map", rop.Success(0), f)
+		assert.ErrorIs(t, result.Err(), rop.ErrInterceptor)
+	}
+}
@@ -0,0 +1,115 @@
+package leak
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	defaultRetryFor = 5 * time.Second
+	maxSleep        = 100 * time.Millisecond
+)
+
+// frames of goroutines owned by the runtime or the testing package
+var ignored = []string{
+	"testing.RunTests(",
+	"testing.(*T).Run(",
+	"testing.(*T).Parallel(",
+	"testing.tRunner(",
+	"testing.(*M).",
+	"testing.runFuzzing(",
+	"testing.runFuzzTests(",
+	"os/signal.signal_recv(",
+	"os/signal.loop(",
+	"runtime.ensureSigM(",
+	"runtime/trace.Start.",
+}
+
+// Find returns the stacks of all goroutines except the calling one and the
+// ones owned by the runtime or the testing package.
+func Find() []string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	stacks := strings.Split(string(buf), "\n\n")
+	leaked := make([]string, 0)
+	for _, stack := range stacks[1:] { // the first one is the current goroutine
+		if stack = strings.TrimSpace(stack); stack == "" || isIgnored(stack) {
+			continue
+		}
+		leaked = append(leaked, stack)
+	}
+	return leaked
+}
+
+// Check waits up to retryFor for the goroutines started elsewhere to exit and
+// returns an error listing the ones that are still running.
+func Check(retryFor time.Duration) error {
+	deadline := time.Now().Add(retryFor)
+	sleep := time.Millisecond
+
+	for {
+		leaked := Find()
+		if len(leaked) == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("found %d unexpected goroutines:\n\n%s",
+				len(leaked), strings.Join(leaked, "\n\n"))
+		}
+
+		time.Sleep(sleep)
+		if sleep < maxSleep {
+			sleep *= 2
+		}
+	}
+}
+
+// VerifyNone fails t if goroutines are left running. Tests calling it must not
+// run in parallel with others.
+func VerifyNone(t testing.TB) {
+	t.Helper()
+	if err := Check(defaultRetryFor); err != nil {
+		t.Error(err)
+	}
+}
+
+// VerifyTestMain runs the tests and fails the whole run if any goroutine
+// outlives them.
+func VerifyTestMain(m *testing.M) {
+	os.Exit(CheckMain(m.Run()))
+}
+
+// CheckMain turns the exit code of a successful test run into a failure when
+// goroutines are left running. Use it in a TestMain that has its own setup.
+func CheckMain(code int) int {
+	if code != 0 {
+		return code
+	}
+	if err := Check(defaultRetryFor); err != nil {
+		fmt.Fprintf(os.Stderr, "leak: %v\n", err)
+		return 1
+	}
+	return code
+}
+
+func isIgnored(stack string) bool {
+	for _, frame := range ignored {
+		if strings.Contains(stack, frame) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package test
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/test/clock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_Limiter_Burst(t *testing.T) {
+	t.Parallel()
+
+	c := clock.NewManual()
+	l := rop.NewLimiter(10, 2, c)
+	ctx := context.Background()
+
+	assert.NoError(t, l.Wait(ctx))
+	assert.NoError(t, l.Wait(ctx))
+
+	done := make(chan error)
+	go func() {
+		done <- l.Wait(ctx)
+	}()
+
+	waitForTimers(c, 1)
+	c.Advance(50 * time.Millisecond)
+	select {
+	case <-done:
+		assert.Fail(t, "token granted too early")
+	default:
+	}
+
+	c.Advance(50 * time.Millisecond)
+	assert.NoError(t, <-done)
+}
+
+func Test_Limiter_Refill(t *testing.T) {
+	t.Parallel()
+
+	c := clock.NewManual()
+	l := rop.NewLimiter(50, 1, c)
+	ctx := context.Background()
+
+	assert.NoError(t, l.Wait(ctx))
+	c.Advance(time.Second) // refills one token only, burst is 1
+	assert.NoError(t, l.Wait(ctx))
+	assert.Equal(t, 0, c.Waiters())
+}
+
+func Test_Limiter_CancelWhileWaiting(t *testing.T) {
+	t.Parallel()
+
+	c := clock.NewManual()
+	l := rop.NewLimiter(1, 1, c)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	assert.NoError(t, l.Wait(ctx))
+
+	done := make(chan error)
+	go func() {
+		done <- l.Wait(ctx)
+	}()
+
+	waitForTimers(c, 1)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	// the token reserved by the cancelled call is given back
+	c.Advance(time.Second)
+	assert.NoError(t, l.Wait(context.Background()))
+}
+
+func Test_Limiter_Disabled(t *testing.T) {
+	t.Parallel()
+
+	l := rop.NewLimiter(0, 1, nil)
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, l.Wait(context.Background()))
+	}
+}
+
+func waitForTimers(c *clock.Manual, count int) {
+	for c.Waiters() < count {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func Test_Limiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	c := clock.NewManual()
+	l := rop.NewLimiter(2, 2, c)
+
+	assert.True(t, l.Allow())
+	assert.True(t, l.Allow())
+	assert.False(t, l.Allow())
+
+	c.Advance(500 * time.Millisecond)
+	assert.True(t, l.Allow())
+	assert.False(t, l.Allow())
+
+	assert.True(t, rop.NewLimiter(0, 1, c).Allow())
+}
@@ -0,0 +1,219 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/logging"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/ib-77/rop/test/clock"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type records struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (r *records) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.buf.Write(p)
+}
+
+func (r *records) list(t *testing.T) []map[string]any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]map[string]any, 0)
+	for _, line := range strings.Split(strings.TrimSpace(r.buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		record := make(map[string]any)
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		list = append(list, record)
+	}
+	return list
+}
+
+func newLogger(opts ...logging.Option) (*logging.Logger, *records) {
+	out := &records{}
+	handler := slog.NewJSONHandler(out, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return logging.New(append([]logging.Option{logging.WithLogger(slog.New(handler))}, opts...)...), out
+}
+
+var tenant = rop.NewKey[string]("tenant")
+var token = rop.NewKey[string]("token")
+
+func Test_Tee(t *testing.T) {
+	t.Parallel()
+
+	l, out := newLogger()
+	ctx := rop.WithStage(context.Background(), "charge")
+
+	input := rop.SetMeta(rop.Fail[int](errors.New("declined")), tenant, "acme")
+	assert.Equal(t, input, logging.Tee(ctx, l, input))
+	logging.Tee(ctx, l, rop.Success(1))
+	logging.Tee(ctx, l, rop.CancelBecause[int](errors.New("late"), context.DeadlineExceeded))
+	logging.Tee(ctx, l, rop.Filtered[int](solo.ErrFiltered))
+
+	list := out.list(t)
+	assert.Len(t, list, 4)
+
+	assert.Equal(t, "ERROR", list[0]["level"])
+	assert.Equal(t, logging.Message, list[0]["msg"])
+	assert.Equal(t, "fail", list[0]["state"])
+	assert.Equal(t, "charge", list[0]["stage"])
+	assert.Equal(t, "declined", list[0]["error"])
+	assert.Equal(t, map[string]any{"tenant": "acme"}, list[0]["meta"])
+
+	assert.Equal(t, "DEBUG", list[1]["level"])
+	assert.Equal(t, "success", list[1]["state"])
+	assert.NotContains(t, list[1], "error")
+
+	assert.Equal(t, "WARN", list[2]["level"])
+	assert.Equal(t, "deadline", list[2]["reason"])
+
+	assert.Equal(t, "filtered", list[3]["state"])
+}
+
+func Test_Levels(t *testing.T) {
+	t.Parallel()
+
+	levels := logging.DefaultLevels
+	levels.Success = slog.LevelInfo
+	l, out := newLogger(logging.WithLevels(levels))
+
+	logging.Log(context.Background(), l, rop.Success(1))
+
+	assert.Equal(t, "INFO", out.list(t)[0]["level"])
+}
+
+func Test_Redact(t *testing.T) {
+	t.Parallel()
+
+	l, out := newLogger(logging.WithRedact(func(a slog.Attr) slog.Attr {
+		if a.Key == "token" || strings.Contains(a.Value.String(), "secret") {
+			return slog.String(a.Key, "***")
+		}
+		return a
+	}))
+
+	r := rop.SetMeta(rop.SetMeta(rop.Fail[int](errors.New("bad secret s3")), token, "t0k3n"), tenant, "acme")
+	logging.Log(context.Background(), l, r)
+
+	record := out.list(t)[0]
+	assert.Equal(t, "***", record["error"])
+	assert.Equal(t, map[string]any{"tenant": "acme", "token": "***"}, record["meta"])
+}
+
+func Test_Sampling(t *testing.T) {
+	t.Parallel()
+
+	l, out := newLogger(logging.WithSampling(3))
+	ctx := context.Background()
+
+	for i := 0; i < 7; i++ {
+		logging.Log(ctx, l, rop.Fail[int](errors.New("flood")))
+		logging.Log(ctx, l, rop.Success(i)) // never sampled
+	}
+
+	failures := make([]map[string]any, 0)
+	for _, record := range out.list(t) {
+		if record["state"] == "fail" {
+			failures = append(failures, record)
+		}
+	}
+	assert.Len(t, failures, 3)
+	assert.NotContains(t, failures[0], "suppressed")
+	assert.Equal(t, float64(2), failures[1]["suppressed"])
+	assert.Equal(t, float64(2), failures[2]["suppressed"])
+}
+
+func Test_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	c := clock.NewManual()
+	l, out := newLogger(logging.WithRateLimit(rop.NewLimiter(1, 2, c)))
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		logging.Log(ctx, l, rop.Cancel[int](errors.New("flood")))
+	}
+	c.Advance(time.Second)
+	logging.Log(ctx, l, rop.Cancel[int](errors.New("flood")))
+
+	list := out.list(t)
+	assert.Len(t, list, 3)
+	assert.Equal(t, float64(3), list[2]["suppressed"])
+}
+
+func Test_Interceptor(t *testing.T) {
+	t.Parallel()
+
+	l, out := newLogger()
+	ctx := rop.WithInterceptors(rop.WithStage(context.Background(), "parse"), l.Interceptor())
+
+	solo.TryWithCtx(ctx, rop.Success("x"), func(_ context.Context, in string) (int, error) {
+		return 0, errors.New("not a number")
+	})
+
+	record := out.list(t)[0]
+	assert.Equal(t, "parse", record["stage"])
+	assert.Equal(t, "try", record["kind"])
+	assert.Equal(t, "not a number", record["error"])
+	assert.Contains(t, record, "duration")
+}
+
+func Test_Operator(t *testing.T) {
+	t.Parallel()
+
+	l, out := newLogger()
+	inputs := make(chan rop.Result[int], 3)
+	inputs <- rop.Success(1)
+	inputs <- rop.Fail[int](errors.New("fail"))
+	inputs <- rop.Success(2)
+	close(inputs)
+
+	count := 0
+	for range mass.Lift(context.Background(), inputs, logging.Operator[int](l), mass.Named("audit")) {
+		count++
+	}
+
+	list := out.list(t)
+	assert.Equal(t, 3, count)
+	assert.Len(t, list, 3)
+	assert.Equal(t, "audit", list[1]["stage"])
+	assert.Equal(t, "fail", list[1]["state"])
+}
+
+func Test_Operator_Cancel(t *testing.T) {
+	t.Parallel()
+
+	l, out := newLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	op := logging.Operator[int](l)
+	success := op.Cancel(ctx, rop.Success(1))
+	failed := op.Cancel(ctx, rop.Fail[int](errors.New("fail")))
+
+	assert.True(t, success.IsCancel())
+	assert.ErrorIs(t, success.Err(), context.Canceled)
+	assert.False(t, failed.IsCancel())
+
+	list := out.list(t)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "cancel", list[0]["state"])
+	assert.Equal(t, context.Canceled.Error(), list[0]["error"])
+	assert.Equal(t, "fail", list[1]["state"])
+}
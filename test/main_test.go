@@ -0,0 +1,10 @@
+package test
+
+import (
+	"github.com/ib-77/rop/test/leak"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	leak.VerifyTestMain(m)
+}
@@ -0,0 +1,46 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+)
+
+func Test_MassUsing_ResourcePerItem(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var acquired, released atomic.Int32
+
+	succeeded := 0
+	for output := range mass.Using(ctx, generateSuccessChan(6),
+		func(_ context.Context, r int) (*atomic.Int32, error) {
+			acquired.Add(1)
+			return &released, nil
+		},
+		func(_ context.Context, r *atomic.Int32) error {
+			r.Add(1)
+			return nil
+		},
+		func(ctx context.Context, in int, r *atomic.Int32) rop.Result[string] {
+			if in%2 == 1 {
+				return rop.Fail[string](errors.New("odd"))
+			}
+			return rop.Success(successConvertIntToStr(ctx, in))
+		}, CancelRopF[int]) {
+
+		if output.IsSuccess() {
+			succeeded++
+		} else {
+			assert.Equal(t, "odd", output.Err().Error())
+		}
+	}
+
+	assert.Equal(t, 3, succeeded)
+	assert.Equal(t, int32(6), acquired.Load())
+	assert.Equal(t, int32(6), released.Load())
+}
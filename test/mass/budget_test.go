@@ -0,0 +1,129 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_MassErrorBudget_Consecutive(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	inputs, done := generateEndlessChan(ctx)
+	fails, cancels := 0, 0
+
+	for output := range mass.ErrorBudget(ctx,
+		mass.Try(ctx,
+			mass.Validate(ctx, inputs, allSuccess[int], CancelF[int], "error"),
+			failFromTen, CancelRopF[int]),
+		mass.Budget{MaxConsecutive: 100}, cancel) {
+
+		if output.IsCancel() {
+			cancels++
+		} else if !output.IsSuccess() {
+			fails++
+		}
+	}
+	<-done
+
+	var budgetErr *mass.BudgetError
+	cause := context.Cause(ctx)
+	assert.ErrorIs(t, cause, mass.ErrBudgetExceeded)
+	assert.True(t, errors.As(cause, &budgetErr))
+	assert.Equal(t, mass.ThresholdConsecutive, budgetErr.Threshold)
+	assert.Equal(t, 100, budgetErr.Consecutive)
+	assert.Equal(t, 110, budgetErr.Seen)
+	assert.Equal(t, "error budget exceeded: 100 consecutive failures (limit 100)", cause.Error())
+	assert.GreaterOrEqual(t, fails, 100)
+	assert.Less(t, fails, 105)
+}
+
+func Test_MassErrorBudget_Ratio(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	inputs := make(chan rop.Result[int], 200)
+	for i := 0; i < 200; i++ {
+		if i%10 == 0 {
+			inputs <- rop.Fail[int](fmt.Errorf("bad row %d", i))
+		} else {
+			inputs <- rop.Success(i)
+		}
+	}
+	close(inputs)
+
+	count := 0
+	for range mass.ErrorBudget(ctx, inputs,
+		mass.Budget{MaxFailRatio: 0.05, MinSamples: 50}, cancel) {
+		count++
+	}
+
+	var budgetErr *mass.BudgetError
+	assert.True(t, errors.As(context.Cause(ctx), &budgetErr))
+	assert.Equal(t, mass.ThresholdRatio, budgetErr.Threshold)
+	assert.Equal(t, 50, budgetErr.Seen)
+	assert.Equal(t, 200, count) // the stage itself passes everything through
+}
+
+func Test_MassErrorBudget_RatioWaitsForDefaultSamples(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	inputs := make(chan rop.Result[int], 40)
+	inputs <- rop.Fail[int](errors.New("bad first row"))
+	inputs <- rop.Fail[int](errors.New("bad second row"))
+	for i := 2; i < 40; i++ {
+		inputs <- rop.Success(i)
+	}
+	close(inputs)
+
+	for range mass.ErrorBudget(ctx, inputs, mass.Budget{MaxFailRatio: 0.05}, cancel) {
+	}
+
+	var budgetErr *mass.BudgetError
+	assert.True(t, errors.As(context.Cause(ctx), &budgetErr))
+	assert.Equal(t, mass.ThresholdRatio, budgetErr.Threshold)
+	assert.Equal(t, mass.DefaultMinSamples, budgetErr.Seen)
+}
+
+func Test_MassErrorBudget_NotExceeded(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	count := 0
+	for range mass.ErrorBudget(ctx, generateSuccessChan(100),
+		mass.Budget{MaxFailRatio: 0.05, MaxConsecutive: 1, MaxFailures: 1}, cancel) {
+		count++
+	}
+
+	assert.Equal(t, 100, count)
+	assert.NoError(t, ctx.Err())
+}
+
+func Test_MassErrorBudget_RequiresStop(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, "mass.ErrorBudget: stop must not be nil", func() {
+		mass.ErrorBudget(context.Background(), generateSuccessChan(1), mass.Budget{MaxFailures: 1}, nil)
+	})
+}
+
+func failFromTen(_ context.Context, r int) (string, error) {
+	if r < 10 {
+		return "ok", nil
+	}
+	return "", fmt.Errorf("cannot import row %d", r)
+}
@@ -0,0 +1,86 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func isEven(_ context.Context, r int) bool {
+	return r%2 == 0
+}
+
+func Test_MassFilter_SkipsDownstream(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	outputs := make([]string, 0)
+
+	for output := range mass.Finally(ctx,
+		mass.Map(ctx,
+			mass.Filter(ctx, generateSuccessChan(6), isEven, CancelRopF[int]),
+			successConvertIntToStr, CancelRopF[int]),
+		func(_ context.Context, r string) string {
+			return r
+		},
+		func(_ context.Context, err error) string {
+			assert.Fail(t, "filtered results must not reach the fail handler", err.Error())
+			return "fail"
+		}, CancelStrF[string]) {
+
+		outputs = append(outputs, output)
+	}
+
+	assert.Equal(t, []string{"0", "2", "4"}, outputs)
+}
+
+func Test_MassCount(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	counts := mass.Count(
+		mass.Check(ctx,
+			mass.Filter(ctx, generateSuccessChan(10), isEven, CancelRopF[int]),
+			func(_ context.Context, r int) bool {
+				return r < 6
+			}, "too big", CancelRopF[int]))
+
+	assert.Equal(t, mass.Counts{Success: 3, Fail: 2, Filtered: 5}, counts)
+	assert.Equal(t, 10, counts.Total())
+}
+
+func Test_MassErrorBudget_IgnoresFiltered(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	counts := mass.Count(
+		mass.ErrorBudget(ctx,
+			mass.Filter(ctx, generateSuccessChan(20), func(_ context.Context, r int) bool {
+				return false
+			}, CancelRopF[int]),
+			mass.Budget{MaxConsecutive: 1}, cancel))
+
+	assert.Equal(t, mass.Counts{Filtered: 20}, counts)
+	assert.NoError(t, ctx.Err())
+}
+
+func Test_MassSkip_KeepsState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	filtered := 0
+	for output := range mass.Try(ctx,
+		mass.Filter(ctx, generateSuccessChan(3), func(_ context.Context, r int) bool {
+			return false
+		}, CancelRopF[int]),
+		successConvertIntToStrWithErr, CancelRopF[int]) {
+
+		assert.True(t, output.IsFiltered())
+		filtered++
+	}
+
+	assert.Equal(t, 3, filtered)
+}
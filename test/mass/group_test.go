@@ -0,0 +1,199 @@
+package mass
+
+import (
+	"context"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/group"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func rejectOdd(_ context.Context, in rop.Result[int]) rop.Result[int] {
+	if in.Result()%2 != 0 {
+		return rop.Fail[int](errOdd)
+	}
+	return in
+}
+
+func Test_MassAndTee(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	counted := func(_ context.Context, in rop.Result[int]) rop.Result[int] {
+		calls.Add(1)
+		return in
+	}
+
+	for _, opts := range [][]mass.Option{nil, {mass.InParallel(group.Parallel{})}} {
+		calls.Store(0)
+		successes, failures := 0, 0
+
+		for output := range mass.AndTee(context.Background(), generateSuccessChan(6),
+			[]func(ctx context.Context, in rop.Result[int]) rop.Result[int]{counted, rejectOdd},
+			CancelRopF[int], opts...) {
+
+			if output.IsSuccess() {
+				assert.Equal(t, 0, output.Result()%2)
+				successes++
+			} else {
+				assert.ErrorIs(t, output.Err(), errOdd)
+				failures++
+			}
+		}
+
+		assert.Equal(t, 3, successes)
+		assert.Equal(t, 3, failures)
+		assert.Equal(t, int32(6), calls.Load())
+	}
+}
+
+func Test_MassAndTee_ParallelPerItem(t *testing.T) {
+	t.Parallel()
+
+	slow := func(ctx context.Context, in rop.Result[int]) rop.Result[int] {
+		time.Sleep(30 * time.Millisecond)
+		return in
+	}
+
+	started := time.Now()
+	for output := range mass.AndTee(context.Background(), generateSuccessChan(2),
+		[]func(ctx context.Context, in rop.Result[int]) rop.Result[int]{slow, slow, slow},
+		CancelRopF[int], mass.InParallel(group.Parallel{})) {
+
+		assert.True(t, output.IsSuccess())
+	}
+
+	assert.Less(t, time.Since(started), 150*time.Millisecond)
+}
+
+func Test_MassAndTee_Cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for output := range mass.AndTee(ctx, generateSuccessChan(3),
+		[]func(ctx context.Context, in rop.Result[int]) rop.Result[int]{rejectOdd},
+		CancelRopF[int]) {
+
+		assert.True(t, output.IsCancel())
+	}
+}
+
+func Test_MassOrSwitch(t *testing.T) {
+	t.Parallel()
+
+	alternatives := []func(ctx context.Context, in rop.Result[int]) (bool, rop.Result[int]){
+		func(_ context.Context, in rop.Result[int]) (bool, rop.Result[int]) {
+			return in.Result() == 1, rop.Fail[int](errOdd)
+		},
+		func(_ context.Context, in rop.Result[int]) (bool, rop.Result[int]) {
+			return in.Result()%2 == 0, rop.Success(in.Result() * 10)
+		},
+	}
+
+	for _, opts := range [][]mass.Option{nil, {mass.InParallel(group.Parallel{Limit: 1})}} {
+		outputs := make([]string, 0)
+
+		for output := range mass.OrSwitch(context.Background(), generateSuccessChan(4), alternatives,
+			successConvertIntToStrResult, CancelRopF[int], opts...) {
+
+			if output.IsSuccess() {
+				outputs = append(outputs, output.Result())
+			} else {
+				outputs = append(outputs, fmt.Sprint(output.Err()))
+			}
+		}
+
+		assert.Equal(t, []string{"0", "odd", "20", "3"}, outputs)
+	}
+}
+
+func Test_MassGroup_Named(t *testing.T) {
+	t.Parallel()
+
+	var kinds []string
+	ctx := rop.WithInterceptors(context.Background(),
+		func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+			if call.Stage == "checks" || call.Stage == "route" {
+				kinds = append(kinds, call.Kind+"("+call.Stage+")")
+			}
+			return next(ctx)
+		})
+
+	for output := range mass.AndTee(ctx, generateSuccessChan(2),
+		[]func(ctx context.Context, in rop.Result[int]) rop.Result[int]{rejectOdd},
+		CancelRopF[int], mass.Named("checks")) {
+
+		assert.Equal(t, []string{"andtee(checks)"}, output.Trail())
+		if !output.IsSuccess() {
+			var stageErr *rop.StageError
+			assert.ErrorAs(t, output.Err(), &stageErr)
+			assert.Equal(t, "checks", stageErr.Stage)
+			assert.ErrorIs(t, output.Err(), errOdd)
+		}
+	}
+
+	alternatives := []func(ctx context.Context, in rop.Result[int]) (bool, rop.Result[int]){
+		func(_ context.Context, in rop.Result[int]) (bool, rop.Result[int]) {
+			return true, in
+		},
+	}
+	for output := range mass.OrSwitch(ctx, generateSuccessChan(1), alternatives,
+		successConvertIntToStrResult, CancelRopF[int], mass.Named("route")) {
+
+		assert.Equal(t, "0", output.Result())
+		assert.Equal(t, []string{"orswitch(route)"}, output.Trail())
+	}
+
+	assert.Equal(t, []string{"andtee(checks)", "group(checks)", "andtee(checks)", "group(checks)",
+		"orswitch(route)", "group(route)"}, kinds)
+}
+
+func Test_MassGroup_PassesUntouchedItems(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ctx := rop.WithInterceptors(context.Background(),
+		func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+			calls.Add(1)
+			return next(ctx)
+		})
+
+	upstream := func() chan rop.Result[int] {
+		inputs := make(chan rop.Result[int], 2)
+		inputs <- rop.Fail[int](errOdd)
+		inputs <- rop.Cancel[int](context.Canceled)
+		close(inputs)
+		return inputs
+	}
+
+	var errs []error
+	for output := range mass.AndTee(ctx, upstream(),
+		[]func(ctx context.Context, in rop.Result[int]) rop.Result[int]{rejectOdd},
+		CancelRopF[int], mass.Named("checks")) {
+
+		assert.Empty(t, output.Trail())
+		errs = append(errs, output.Err())
+	}
+
+	alternatives := []func(ctx context.Context, in rop.Result[int]) (bool, rop.Result[int]){
+		func(_ context.Context, in rop.Result[int]) (bool, rop.Result[int]) {
+			return true, in
+		},
+	}
+	for output := range mass.OrSwitch(ctx, upstream(), alternatives,
+		successConvertIntToStrResult, CancelRopF[int], mass.Named("route")) {
+
+		assert.Empty(t, output.Trail())
+		assert.Equal(t, output.Err() == context.Canceled, output.IsCancel())
+		errs = append(errs, output.Err())
+	}
+
+	assert.Equal(t, []error{errOdd, context.Canceled, errOdd, context.Canceled}, errs)
+	assert.Zero(t, calls.Load())
+}
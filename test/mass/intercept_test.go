@@ -0,0 +1,61 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+)
+
+func Test_MassInterceptors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	var stage atomic.Value
+	ctx := rop.WithInterceptors(context.Background(),
+		func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+			calls.Add(1)
+			stage.Store(call.Stage + "/" + call.Kind)
+			return next(ctx)
+		})
+
+	for output := range mass.Lift(ctx,
+		mass.Map(ctx, generateSuccessChan(3), successConvertIntToStr, CancelRopF[int], mass.Named("format")),
+		rop.NewOperator(
+			func(_ context.Context, in rop.Result[string]) rop.Result[string] { return in },
+			func(_ context.Context, in rop.Result[string]) rop.Result[string] { return in })) {
+
+		assert.True(t, output.IsSuccess())
+	}
+
+	assert.Equal(t, int32(6), calls.Load())
+	assert.Equal(t, "/lift", stage.Load())
+}
+
+func Test_MassInterceptors_Cancel(t *testing.T) {
+	t.Parallel()
+
+	var cancels atomic.Int32
+	ctx, cancel := context.WithCancel(rop.WithInterceptors(context.Background(),
+		func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+			out := next(ctx)
+			if call.Kind == "cancel" && out.IsCancel() {
+				cancels.Add(1)
+			}
+			return out
+		}))
+	cancel()
+
+	for range mass.Finally(ctx,
+		mass.Map(ctx, generateSuccessChan(3), successConvertIntToStr, CancelRopF[int]),
+		func(_ context.Context, r string) string { return r },
+		func(_ context.Context, err error) string { return err.Error() },
+		func(_ context.Context, r rop.Result[string]) string { return "cancelled" },
+		mass.WithCancelPolicy(mass.CancelAll)) {
+	}
+
+	// reported by Map and then by the cancel handler of Finally
+	assert.Equal(t, int32(6), cancels.Load())
+}
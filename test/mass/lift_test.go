@@ -0,0 +1,86 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var errCancelledSquare = errors.New("square cancelled")
+
+func square() rop.Operator[int, int] {
+	return rop.NewOperator(
+		func(_ context.Context, in rop.Result[int]) rop.Result[int] {
+			if !in.IsSuccess() {
+				return in
+			}
+			if in.Result() == 3 {
+				panic("three")
+			}
+			return rop.Success(in.Result() * in.Result())
+		},
+		func(_ context.Context, in rop.Result[int]) rop.Result[int] {
+			return rop.Cancel[int](errCancelledSquare)
+		})
+}
+
+func Test_MassLift(t *testing.T) {
+	t.Parallel()
+
+	var successes []int
+	var panicErr *rop.PanicError
+	for output := range mass.Lift(context.Background(), generateSuccessChan(5), square()) {
+		if output.IsSuccess() {
+			successes = append(successes, output.Result())
+		} else {
+			assert.ErrorAs(t, output.Err(), &panicErr)
+		}
+	}
+
+	assert.Equal(t, []int{0, 1, 4, 16}, successes)
+	assert.Equal(t, "three", panicErr.Value)
+}
+
+func Test_MassLift_Cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	count := 0
+	for output := range mass.Lift(ctx, generateSuccessChan(3), square(),
+		mass.WithCancelPolicy(mass.CancelAll)) {
+
+		assert.Equal(t, rop.Cancel[int](errCancelledSquare), output)
+		count++
+	}
+	assert.Equal(t, 3, count)
+}
+
+func Test_MassLift_Named(t *testing.T) {
+	t.Parallel()
+
+	var stageErr *rop.StageError
+	for output := range mass.Lift(context.Background(), generateSuccessChan(4), rop.NewOperator(
+		func(_ context.Context, in rop.Result[int]) rop.Result[int] {
+			if in.Result()%2 != 0 {
+				return rop.Fail[int](errOdd)
+			}
+			return in
+		},
+		func(_ context.Context, in rop.Result[int]) rop.Result[int] {
+			return rop.Cancel[int](errCancelledSquare)
+		}), mass.Named("even")) {
+
+		assert.Equal(t, []string{"lift(even)"}, output.Trail())
+		if !output.IsSuccess() {
+			assert.ErrorAs(t, output.Err(), &stageErr)
+			assert.Equal(t, "even", stageErr.Stage)
+			assert.ErrorIs(t, output.Err(), errOdd)
+		}
+	}
+	assert.NotNil(t, stageErr)
+}
@@ -0,0 +1,181 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_MassTake_StopsUnboundedUpstream(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	inputs, done := generateEndlessChan(ctx)
+	taken := make([]string, 0)
+
+	for output := range mass.Take(ctx,
+		mass.Map(ctx,
+			mass.Validate(ctx, inputs, allSuccess[int], CancelF[int], "error"),
+			successConvertIntToStr, CancelRopF[int]),
+		3, cancel) {
+
+		assert.True(t, output.IsSuccess())
+		taken = append(taken, output.Result())
+	}
+
+	assert.Equal(t, []string{"0", "1", "2"}, taken)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		assert.Fail(t, "upstream generator was not stopped")
+	}
+}
+
+func Test_MassTake_CountsOnlySuccess(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	inputs := make(chan rop.Result[int], 5)
+	inputs <- rop.Fail[int](errors.New("fail"))
+	inputs <- rop.Success(1)
+	inputs <- rop.Cancel[int](errors.New("cancel"))
+	inputs <- rop.Success(2)
+	inputs <- rop.Success(3)
+	close(inputs)
+
+	outputs := make([]rop.Result[int], 0)
+	for output := range mass.Take(ctx, inputs, 2, cancel) {
+		outputs = append(outputs, output)
+	}
+
+	assert.Equal(t, []rop.Result[int]{
+		rop.Fail[int](errors.New("fail")),
+		rop.Success(1),
+		rop.Cancel[int](errors.New("cancel")),
+		rop.Success(2),
+	}, outputs)
+	assert.Error(t, ctx.Err())
+}
+
+func Test_MassTake_Zero(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	inputs, done := generateEndlessChan(ctx)
+	count := 0
+
+	for range mass.Take(ctx,
+		mass.Validate(ctx, inputs, allSuccess[int], CancelF[int], "error"), 0, cancel) {
+		count++
+	}
+
+	assert.Equal(t, 0, count)
+	<-done
+}
+
+func Test_MassTakeWhile(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	inputs, done := generateEndlessChan(ctx)
+	taken := make([]int, 0)
+
+	for output := range mass.TakeWhile(ctx,
+		mass.Validate(ctx, inputs, allSuccess[int], CancelF[int], "error"),
+		func(_ context.Context, r int) bool {
+			return r < 4
+		}, cancel) {
+
+		taken = append(taken, output.Result())
+	}
+
+	assert.Equal(t, []int{0, 1, 2, 3}, taken)
+	<-done
+}
+
+func Test_MassTake_RequiresStop(t *testing.T) {
+	t.Parallel()
+
+	inputs := make(chan rop.Result[int])
+	defer close(inputs)
+
+	assert.PanicsWithValue(t, "mass.Take: stop must not be nil", func() {
+		mass.Take(context.Background(), inputs, 1, nil)
+	})
+	assert.PanicsWithValue(t, "mass.TakeWhile: stop must not be nil", func() {
+		mass.TakeWhile(context.Background(), inputs, func(context.Context, int) bool {
+			return true
+		}, nil)
+	})
+}
+
+func Test_MassSkip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	inputs := generateUnbufferedChan(5)
+	skipped := make([]int, 0)
+
+	for output := range mass.Skip(ctx,
+		mass.Validate(ctx, inputs, func(_ context.Context, i int) bool {
+			return i != 1
+		}, CancelF[int], "error"), 2) {
+
+		if output.IsSuccess() {
+			skipped = append(skipped, output.Result())
+		} else {
+			assert.Equal(t, "error", output.Err().Error())
+		}
+	}
+
+	assert.Equal(t, []int{3, 4}, skipped)
+}
+
+func Test_MassSkipWhile(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	inputs := generateUnbufferedChan(5)
+	skipped := make([]int, 0)
+
+	for output := range mass.SkipWhile(ctx,
+		mass.Validate(ctx, inputs, allSuccess[int], CancelF[int], "error"),
+		func(_ context.Context, r int) bool {
+			return r%2 == 0
+		}) {
+
+		skipped = append(skipped, output.Result())
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4}, skipped)
+}
+
+func generateEndlessChan(ctx context.Context) (chan int, <-chan struct{}) {
+	inputs := make(chan int)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer close(inputs)
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case inputs <- i:
+			}
+		}
+	}()
+	return inputs, done
+}
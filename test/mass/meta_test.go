@@ -0,0 +1,48 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+var correlationKey = rop.NewKey[string]("correlation-id")
+
+func Test_MassMeta_ThroughStages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	inputs := make(chan rop.Result[int], 3)
+	for i := 0; i < 3; i++ {
+		inputs <- rop.SetMeta(rop.Success(i), correlationKey, "c-"+strconv.Itoa(i))
+	}
+	close(inputs)
+
+	reports := make([]string, 0)
+	for report := range mass.Finally(ctx,
+		mass.Try(ctx,
+			mass.Switch(ctx,
+				mass.Map(ctx, inputs, successConvertIntToStr, CancelRopF[int]),
+				func(_ context.Context, r string) rop.Result[string] {
+					return rop.Success("#" + r)
+				}, CancelRopF[string]),
+			func(ctx context.Context, r string) (string, error) {
+				id, _ := rop.GetMetaFromCtx(ctx, correlationKey)
+				return r + "@" + id, nil
+			}, CancelRopF[string]),
+		func(ctx context.Context, r string) string {
+			id, _ := rop.GetMetaFromCtx(ctx, correlationKey)
+			return r + "/" + id
+		},
+		func(_ context.Context, err error) string {
+			return err.Error()
+		}, CancelStrF[string]) {
+
+		reports = append(reports, report)
+	}
+
+	assert.Equal(t, []string{"#0@c-0/c-0", "#1@c-1/c-1", "#2@c-2/c-2"}, reports)
+}
@@ -0,0 +1,64 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_MassMap_RecoversPanicPerItem(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	successes := make([]string, 0)
+	panics := 0
+
+	for output := range mass.Map(ctx, generateSuccessChan(5),
+		func(ctx context.Context, r int) string {
+			if r == 2 {
+				panic("boom")
+			}
+			return successConvertIntToStr(ctx, r)
+		}, CancelRopF[int]) {
+
+		if output.IsSuccess() {
+			successes = append(successes, output.Result())
+			continue
+		}
+
+		var panicErr *rop.PanicError
+		assert.ErrorAs(t, output.Err(), &panicErr)
+		assert.Equal(t, "boom", panicErr.Value)
+		assert.NotEmpty(t, panicErr.Stack)
+		panics++
+	}
+
+	assert.Equal(t, []string{"0", "1", "3", "4"}, successes)
+	assert.Equal(t, 1, panics)
+}
+
+func Test_MassFinally_RoutesPanicToFailF(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	outputs := make([]string, 0)
+
+	for output := range mass.Finally(ctx, generateSuccessChan(3),
+		func(ctx context.Context, r int) string {
+			if r == 1 {
+				panic(errors.New("boom"))
+			}
+			return successFinally(ctx, r)
+		},
+		func(_ context.Context, err error) string {
+			return err.Error()
+		}, CancelStrF[int]) {
+
+		outputs = append(outputs, output)
+	}
+
+	assert.Equal(t, []string{"ok", "panic: boom", "ok"}, outputs)
+}
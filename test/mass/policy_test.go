@@ -0,0 +1,137 @@
+package mass
+
+import (
+	"context"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_MassPolicy_CancelAll(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var cancelled atomic.Int32
+	count := 0
+	for output := range mass.Map(ctx, generateSuccessChan(10),
+		successConvertIntToStr, countingCancelF[int](&cancelled),
+		mass.WithCancelPolicy(mass.CancelAll)) {
+
+		assert.True(t, output.IsCancel())
+		count++
+	}
+
+	assert.Equal(t, 10, count)
+	assert.Equal(t, int32(10), cancelled.Load())
+}
+
+func Test_MassPolicy_DropRemaining(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var cancelled atomic.Int32
+	inputs := generateSuccessChan(10)
+	count := 0
+	for range mass.Try(ctx, inputs,
+		successConvertIntToStrWithErr, countingCancelF[int](&cancelled),
+		mass.WithCancelPolicy(mass.DropRemaining)) {
+		count++
+	}
+
+	assert.Equal(t, 0, count)
+	assert.Equal(t, int32(0), cancelled.Load())
+	assert.Empty(t, inputs) // drained
+}
+
+func Test_MassPolicy_StopImmediately(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	inputs := make(chan rop.Result[int]) // never closed
+
+	var cancelled atomic.Int32
+	outputs := mass.Tee(ctx, inputs, func(context.Context, rop.Result[int]) {},
+		countingCancelF[int](&cancelled), mass.WithCancelPolicy(mass.StopImmediately))
+
+	inputs <- rop.Success(1)
+	assert.Equal(t, rop.Success(1), <-outputs)
+
+	cancel()
+
+	select {
+	case _, ok := <-outputs:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		assert.Fail(t, "stage did not stop")
+	}
+	assert.Equal(t, int32(0), cancelled.Load())
+}
+
+func Test_MassPolicy_CancelWithin(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var cancelled atomic.Int32
+	slowCancelF := func(ctx context.Context, in int) error {
+		cancelled.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return fmt.Errorf("cancelled %d", in)
+	}
+
+	count := 0
+	for output := range mass.Check(ctx, generateSuccessChan(100),
+		func(context.Context, int) bool { return true }, "false", slowCancelF,
+		mass.WithCancelPolicy(mass.CancelWithin(100*time.Millisecond))) {
+
+		assert.True(t, output.IsCancel())
+		count++
+	}
+
+	assert.Greater(t, count, 0)
+	assert.Less(t, count, 100)
+	assert.Equal(t, int32(count), cancelled.Load())
+}
+
+func Test_MassPolicy_Finally(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	count := 0
+	for range mass.Finally(ctx, generateSuccessChan(10),
+		successFinally, failConvertIntToStrProcessError, CancelStrF[int],
+		mass.WithCancelPolicy(mass.DropRemaining)) {
+		count++
+	}
+
+	assert.Equal(t, 0, count)
+}
+
+func generateSuccessChan(amount int) chan rop.Result[int] {
+	inputs := make(chan rop.Result[int], amount)
+	defer close(inputs)
+
+	for i := 0; i < amount; i++ {
+		inputs <- rop.Success(i)
+	}
+
+	return inputs
+}
+
+func countingCancelF[T any](counter *atomic.Int32) func(ctx context.Context, in T) error {
+	return func(ctx context.Context, in T) error {
+		counter.Add(1)
+		return fmt.Errorf("---- processing of value %v was cancelled", in)
+	}
+}
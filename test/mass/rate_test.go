@@ -0,0 +1,78 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/ib-77/rop/test/clock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_MassRateLimit(t *testing.T) {
+	t.Parallel()
+
+	c := clock.NewManual()
+	limiter := rop.NewLimiter(1, 2, c)
+	ctx := context.Background()
+
+	outputs := mass.RateLimit(ctx, generateSuccessChan(3), limiter, CancelRopF[int])
+
+	assert.Equal(t, rop.Success(0), <-outputs)
+	assert.Equal(t, rop.Success(1), <-outputs)
+
+	waitForTimers(c, 1)
+	c.Advance(time.Second)
+	assert.Equal(t, rop.Success(2), <-outputs)
+
+	_, ok := <-outputs
+	assert.False(t, ok)
+}
+
+func Test_MassTry_WithRateLimit_CancelWhileWaiting(t *testing.T) {
+	t.Parallel()
+
+	c := clock.NewManual()
+	limiter := rop.NewLimiter(1, 1, c)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	outputs := mass.Try(ctx, generateSuccessChan(3),
+		successConvertIntToStrWithErr, CancelRopF[int], mass.WithRateLimit(limiter))
+
+	assert.Equal(t, rop.Success("0"), <-outputs)
+
+	waitForTimers(c, 1)
+	cancel()
+
+	for output := range outputs {
+		assert.True(t, output.IsCancel())
+	}
+}
+
+func Test_MassRateLimit_SkipsFailed(t *testing.T) {
+	t.Parallel()
+
+	c := clock.NewManual()
+	limiter := rop.NewLimiter(1, 1, c)
+	ctx := context.Background()
+
+	count := 0
+	for output := range mass.RateLimit(ctx,
+		mass.Validate(ctx, generateBufferedChan(5, 5), allFail[int], CancelF[int], "error"),
+		limiter, CancelRopF[int]) {
+
+		assert.False(t, output.IsSuccess())
+		count++
+	}
+
+	assert.Equal(t, 5, count)
+	assert.Equal(t, 0, c.Waiters())
+}
+
+func waitForTimers(c *clock.Manual, count int) {
+	for c.Waiters() < count {
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,74 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_MassCancelReason_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	inputs, done := generateEndlessChan(ctx)
+	reasons := make(map[rop.CancelReason]int)
+
+	for output := range mass.Map(ctx,
+		mass.Validate(ctx, inputs, allSuccess[int], CancelF[int], "error"),
+		func(_ context.Context, r int) int {
+			if r == 5 {
+				cancel(rop.ErrShutdown)
+			}
+			return r
+		}, CancelRopF[int]) {
+
+		if output.IsCancel() {
+			assert.True(t, output.IsShutdown())
+			assert.Equal(t, rop.ErrShutdown, output.CancelCause())
+		}
+		reasons[output.CancelReason()]++
+	}
+	<-done
+
+	assert.Equal(t, 6, reasons[rop.CancelUnspecified])
+}
+
+func Test_MassCancelReason_ReachesFinally(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	reasons := make(map[string]int)
+
+	// Finally does not share the context stopped by the budget, so every
+	// failure that got through is handled as one
+	for reason := range mass.Finally(context.Background(),
+		mass.ErrorBudget(ctx,
+			mass.Try(ctx,
+				mass.Validate(ctx, generateBufferedChan(20, 20), allSuccess[int], CancelF[int], "error"),
+				failFromTen, CancelRopF[int]),
+			mass.Budget{MaxFailures: 5}, cancel),
+		func(_ context.Context, r string) string {
+			return "success"
+		},
+		func(ctx context.Context, err error) string {
+			return rop.GetCancelReasonFromCtx(ctx).String()
+		},
+		func(ctx context.Context, r rop.Result[string]) string {
+			return rop.GetCancelReasonFromCtx(ctx).String()
+		}) {
+
+		reasons[reason]++
+	}
+
+	assert.Equal(t, 10, reasons["success"])
+	// the row after the tripping one may have been tried before the trip
+	assert.Contains(t, []int{5, 6}, reasons["unspecified"], reasons)
+	assert.Equal(t, 10, reasons["unspecified"]+reasons["breaker"], reasons)
+	assert.Zero(t, reasons["aborted"])
+}
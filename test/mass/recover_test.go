@@ -0,0 +1,78 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var errOdd = errors.New("odd")
+
+func Test_MassRecover(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	values := make([]int, 0)
+
+	for output := range mass.Recover(ctx, failOdd(ctx, generateSuccessChan(4)),
+		func(context.Context, error) int { return -1 }, solo.ErrorIs(errOdd), CancelRopF[int]) {
+
+		assert.True(t, output.IsSuccess())
+		values = append(values, output.Result())
+	}
+
+	assert.Equal(t, []int{0, -1, 2, -1}, values)
+}
+
+func Test_MassRecoverWith(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	outputs := make([]rop.Result[int], 0)
+
+	for output := range mass.RecoverWith(ctx, failOdd(ctx, generateSuccessChan(2)),
+		func(context.Context, error) rop.Result[int] { return rop.Fail[int](errors.New("still odd")) },
+		nil, CancelRopF[int]) {
+
+		outputs = append(outputs, output)
+	}
+
+	assert.Equal(t, []rop.Result[int]{rop.Success(0), rop.Fail[int](errors.New("still odd"))}, outputs)
+}
+
+func Test_MassOrElseAndFailToCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	count := 0
+	for output := range mass.OrElse(ctx, failOdd(ctx, generateSuccessChan(4)), 0,
+		solo.ErrorIs(errOdd), CancelRopF[int]) {
+		assert.True(t, output.IsSuccess())
+		count++
+	}
+	assert.Equal(t, 4, count)
+
+	cancels := 0
+	for output := range mass.CancelToFail(ctx,
+		mass.FailToCancel(ctx, failOdd(ctx, generateSuccessChan(4)), nil, CancelRopF[int]),
+		solo.ErrorIs(context.Canceled), CancelRopF[int]) {
+		if output.IsCancel() {
+			cancels++
+		}
+	}
+	assert.Equal(t, 2, cancels)
+}
+
+func failOdd(ctx context.Context, inputs <-chan rop.Result[int]) <-chan rop.Result[int] {
+	return mass.Switch(ctx, inputs, func(_ context.Context, r int) rop.Result[int] {
+		if r%2 == 1 {
+			return rop.Fail[int](errOdd)
+		}
+		return rop.Success(r)
+	}, CancelRopF[int])
+}
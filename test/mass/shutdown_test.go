@@ -0,0 +1,141 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/ib-77/rop/test/leak"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_MassStages_ExitWithoutReader(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	inputs, done := generateEndlessChan(ctx)
+
+	outputs := mass.Finally(ctx,
+		mass.Check(ctx,
+			mass.Tee(ctx,
+				mass.Try(ctx,
+					mass.Map(ctx,
+						mass.AndValidate(ctx,
+							mass.Validate(ctx, inputs, allSuccess[int], CancelF[int], "error"),
+							allSuccess[int], CancelRopF[int], "and error"),
+						successConvertIntToStr, CancelRopF[int]),
+					func(_ context.Context, r string) (string, error) { return r, nil }, CancelRopF[string]),
+				func(context.Context, rop.Result[string]) {}, CancelRopF[string]),
+			func(context.Context, string) bool { return true }, "false", CancelRopF[string]),
+		func(context.Context, bool) string { return "ok" },
+		failConvertIntToStrProcessError, CancelStrF[bool])
+
+	assert.Equal(t, "ok", <-outputs)
+	assert.Equal(t, "ok", <-outputs)
+
+	cancel() // and never read again
+	<-done
+
+	leak.VerifyNone(t)
+}
+
+func Test_MassTake_ExitWithoutReader(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	inputs, done := generateEndlessChan(ctx)
+
+	outputs := mass.Take(ctx,
+		mass.Validate(ctx, inputs, allSuccess[int], CancelF[int], "error"), 10, cancel)
+
+	assert.True(t, (<-outputs).IsSuccess())
+
+	cancel()
+	<-done
+
+	leak.VerifyNone(t)
+}
+
+func Test_MassStage_ExitsWithoutReader(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	inputs, done := generateEndlessChan(ctx)
+
+	outputs := mass.Validate(ctx, inputs, allSuccess[int], CancelF[int], "error")
+	assert.True(t, (<-outputs).IsSuccess())
+
+	cancel() // and never read again
+	<-done
+
+	leak.VerifyNone(t)
+}
+
+func Test_MassStage_ExitsOnIdleInput(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	inputs := make(chan int) // idle and never closed
+
+	outputs := mass.Validate(ctx, inputs, allSuccess[int], CancelF[int], "error")
+	cancel()
+
+	select {
+	case _, ok := <-outputs:
+		assert.False(t, ok)
+	case <-time.After(2 * mass.IdleTimeout):
+		assert.Fail(t, "stage is still waiting for input")
+	}
+
+	leak.VerifyNone(t)
+}
+
+func Test_MassCancelAll_WaitsForSlowConsumer(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	inputs := make(chan int, 3)
+	for i := 0; i < 3; i++ {
+		inputs <- i
+	}
+	close(inputs)
+	cancel()
+
+	cancelled := 0
+	for output := range mass.Validate(ctx, inputs, allSuccess[int], CancelF[int], "error",
+		mass.WithDrainTimeout(-1)) {
+		assert.True(t, output.IsCancel())
+		cancelled++
+		time.Sleep(mass.DrainTimeout + 100*time.Millisecond)
+	}
+
+	assert.Equal(t, 3, cancelled)
+}
+
+func Test_MassCancelAll_WaitsForSlowStageInFront(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	inputs := make(chan int, 3)
+	for i := 0; i < 3; i++ {
+		inputs <- i
+	}
+	close(inputs)
+	cancel()
+
+	slowCancelF := func(ctx context.Context, in int) error {
+		time.Sleep(mass.IdleTimeout + 100*time.Millisecond)
+		return CancelF(ctx, in)
+	}
+
+	cancelled := 0
+	for output := range mass.Map(ctx,
+		mass.Validate(ctx, inputs, allSuccess[int], slowCancelF, "error"),
+		successConvertIntToStr, CancelRopF[int], mass.WithIdleTimeout(-1)) {
+
+		assert.True(t, output.IsCancel())
+		cancelled++
+	}
+
+	assert.Equal(t, 3, cancelled)
+}
@@ -0,0 +1,86 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_MassNamed_Breadcrumb(t *testing.T) {
+	t.Parallel()
+
+	ctx := rop.WithTrail(context.Background())
+	errDeclined := errors.New("card declined")
+
+	reports := make([]string, 0)
+	for report := range mass.Finally(ctx,
+		mass.Try(ctx,
+			mass.Map(ctx, generateSuccessChan(2), successConvertIntToStr, CancelRopF[int]),
+			func(_ context.Context, r string) (int, error) {
+				if r == "1" {
+					return 0, errDeclined
+				}
+				return 1, nil
+			}, CancelRopF[string], mass.Named("charge")),
+		func(ctx context.Context, r int) string {
+			return rop.Breadcrumb(rop.GetTrailFromCtx(ctx), nil)
+		},
+		func(ctx context.Context, err error) string {
+			assert.ErrorIs(t, err, errDeclined)
+			return rop.Breadcrumb(rop.GetTrailFromCtx(ctx), err)
+		}, CancelStrF[int]) {
+
+		reports = append(reports, report)
+	}
+
+	assert.ElementsMatch(t, []string{"map→try(charge)", "map→try(charge): card declined"}, reports)
+}
+
+func Test_MassNamed_LimitStages(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	budgetCtx, stop := context.WithCancelCause(context.Background())
+	defer stop(nil)
+
+	outputs := make([]rop.Result[int], 0)
+	for output := range mass.Take(ctx,
+		mass.SkipWhile(ctx,
+			mass.Skip(ctx,
+				mass.ErrorBudget(budgetCtx, generateSuccessChan(5), mass.Budget{MaxFailures: 1}, stop,
+					mass.Named("budget")),
+				1, mass.Named("skip")),
+			func(_ context.Context, r int) bool { return r < 2 }, mass.Named("skipwhile")),
+		2, cancel, mass.Named("take")) {
+
+		outputs = append(outputs, output)
+	}
+
+	assert.Len(t, outputs, 2)
+	for i, output := range outputs {
+		assert.Equal(t, i+2, output.Result())
+		assert.Equal(t, []string{"errorbudget(budget)", "skip(skip)", "skipwhile(skipwhile)", "take(take)"},
+			output.Trail())
+	}
+}
+
+func Test_MassNamed_TakeWhile(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	outputs := make([]rop.Result[int], 0)
+	for output := range mass.TakeWhile(ctx, generateSuccessChan(5),
+		func(_ context.Context, r int) bool { return r < 1 }, cancel, mass.Named("head")) {
+
+		outputs = append(outputs, output)
+	}
+
+	assert.Len(t, outputs, 1)
+	assert.Equal(t, []string{"takewhile(head)"}, outputs[0].Trail())
+}
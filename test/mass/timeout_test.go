@@ -0,0 +1,35 @@
+package mass
+
+import (
+	"context"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_MassTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	hangOnOdd := func(ctx context.Context, r int) rop.Result[int] {
+		if r%2 == 1 {
+			<-ctx.Done()
+		}
+		return rop.Success(r)
+	}
+
+	outputs := make([]rop.Result[int], 0)
+	for output := range mass.Timeout(ctx, generateSuccessChan(4),
+		20*time.Millisecond, solo.TimeoutFail, hangOnOdd, CancelRopF[int]) {
+		outputs = append(outputs, output)
+	}
+
+	assert.Len(t, outputs, 4)
+	assert.Equal(t, rop.Success(0), outputs[0])
+	assert.ErrorIs(t, outputs[1].Err(), solo.ErrStepTimeout)
+	assert.Equal(t, rop.Success(2), outputs[2])
+	assert.ErrorIs(t, outputs[3].Err(), solo.ErrStepTimeout)
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var (
+	correlationKey = rop.NewKey[string]("correlation-id")
+	attemptKey     = rop.NewKey[int]("attempt")
+)
+
+func Test_Meta_SetAndGet(t *testing.T) {
+	t.Parallel()
+
+	plain := rop.Success(1)
+	tagged := rop.SetMeta(plain, correlationKey, "c-1")
+
+	id, ok := rop.GetMeta(tagged, correlationKey)
+	assert.True(t, ok)
+	assert.Equal(t, "c-1", id)
+
+	_, ok = rop.GetMeta(plain, correlationKey)
+	assert.False(t, ok, "the original result must not change")
+
+	_, ok = rop.GetMeta(tagged, attemptKey)
+	assert.False(t, ok)
+
+	retagged := rop.SetMeta(tagged, correlationKey, "c-2")
+	id, _ = rop.GetMeta(retagged, correlationKey)
+	assert.Equal(t, "c-2", id)
+	id, _ = rop.GetMeta(tagged, correlationKey)
+	assert.Equal(t, "c-1", id)
+
+	assert.Equal(t, []string{"correlation-id"}, rop.MetaKeys(retagged))
+}
+
+func Test_Meta_KeysAreTyped(t *testing.T) {
+	t.Parallel()
+
+	sameName := rop.NewKey[int]("correlation-id")
+	tagged := rop.SetMeta(rop.Success(1), correlationKey, "c-1")
+
+	_, ok := rop.GetMeta(tagged, sameName)
+	assert.False(t, ok)
+}
+
+func Test_Meta_Carry(t *testing.T) {
+	t.Parallel()
+
+	from := rop.SetMeta(rop.SetMeta(rop.Success(1), correlationKey, "c-1"), attemptKey, 1)
+	to := rop.SetMeta(rop.Fail[string](errors.New("fail")), attemptKey, 2)
+
+	carried := rop.Carry(from, to)
+
+	id, _ := rop.GetMeta(carried, correlationKey)
+	attempt, _ := rop.GetMeta(carried, attemptKey)
+	assert.Equal(t, "c-1", id)
+	assert.Equal(t, 2, attempt)
+	assert.Equal(t, "fail", carried.Err().Error())
+
+	derived := rop.SetMeta(from, attemptKey, 3)
+	assert.Equal(t, derived, rop.Carry(from, derived))
+}
+
+func Test_Meta_FromCtx(t *testing.T) {
+	t.Parallel()
+
+	tagged := rop.SetMeta(rop.Success(1), correlationKey, "c-1")
+	ctx := rop.WithResultOf(context.Background(), tagged)
+
+	id, ok := rop.GetMetaFromCtx(ctx, correlationKey)
+	assert.True(t, ok)
+	assert.Equal(t, "c-1", id)
+
+	_, ok = rop.GetMetaFromCtx(context.Background(), correlationKey)
+	assert.False(t, ok)
+}
@@ -0,0 +1,253 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/bridge"
+	"github.com/ib-77/rop/pkg/rop/fan"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/ib-77/rop/pkg/rop/metrics"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_Registry_Exposition(t *testing.T) {
+	t.Parallel()
+
+	r := metrics.NewRegistry(0.1, 1)
+	r.Observe("charge", "try", metrics.Success, 50*time.Millisecond)
+	r.Observe("charge", "try", metrics.Fail, 500*time.Millisecond)
+	r.Observe("charge", "try", metrics.Success, 2*time.Second)
+	r.Observe(`we"ird`, "map", metrics.Filtered, 0)
+	r.AddQueueDepth("charge", 3)
+	r.AddQueueDepth("charge", -1)
+
+	var b strings.Builder
+	_, err := r.WriteTo(&b)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `# HELP rop_stage_results_total Results produced by stage user functions.
+# TYPE rop_stage_results_total counter
+rop_stage_results_total{stage="charge",kind="try",state="fail"} 1
+rop_stage_results_total{stage="charge",kind="try",state="success"} 2
+rop_stage_results_total{stage="we\"ird",kind="map",state="filtered"} 1
+# HELP rop_stage_duration_seconds Duration of stage user function calls.
+# TYPE rop_stage_duration_seconds histogram
+rop_stage_duration_seconds_bucket{stage="charge",kind="try",le="0.1"} 1
+rop_stage_duration_seconds_bucket{stage="charge",kind="try",le="1"} 2
+rop_stage_duration_seconds_bucket{stage="charge",kind="try",le="+Inf"} 3
+rop_stage_duration_seconds_sum{stage="charge",kind="try"} 2.55
+rop_stage_duration_seconds_count{stage="charge",kind="try"} 3
+rop_stage_duration_seconds_bucket{stage="we\"ird",kind="map",le="0.1"} 1
+rop_stage_duration_seconds_bucket{stage="we\"ird",kind="map",le="1"} 1
+rop_stage_duration_seconds_bucket{stage="we\"ird",kind="map",le="+Inf"} 1
+rop_stage_duration_seconds_sum{stage="we\"ird",kind="map"} 0
+rop_stage_duration_seconds_count{stage="we\"ird",kind="map"} 1
+# HELP rop_stage_queue_depth Items waiting in front of a stage.
+# TYPE rop_stage_queue_depth gauge
+rop_stage_queue_depth{stage="charge"} 2
+`, b.String())
+}
+
+func Test_Registry_Handler(t *testing.T) {
+	t.Parallel()
+
+	r := metrics.NewRegistry()
+	r.Observe("a", "map", metrics.Success, time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, _ := io.ReadAll(rec.Body)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, string(body), `rop_stage_results_total{stage="a",kind="map",state="success"} 1`)
+	assert.Contains(t, string(body), `rop_stage_duration_seconds_bucket{stage="a",kind="map",le="0.005"} 1`)
+}
+
+func Test_Interceptor_CountsPerStage(t *testing.T) {
+	t.Parallel()
+
+	r := metrics.NewRegistry()
+	ctx := rop.WithInterceptors(context.Background(), metrics.Interceptor(r))
+
+	inputs := make(chan int, 4)
+	for _, v := range []int{1, -1, 2, 3} {
+		inputs <- v
+	}
+	close(inputs)
+
+	for range mass.Filter(ctx,
+		mass.Validate(ctx, inputs, func(_ context.Context, in int) bool { return in > 0 },
+			cancelF[int], "negative", mass.Named("positive")),
+		func(_ context.Context, in int) bool { return in != 2 }, cancelF[int], mass.Named("no-twos")) {
+	}
+
+	assert.Equal(t, int64(3), r.Count("positive", "validate", metrics.Success))
+	assert.Equal(t, int64(1), r.Count("positive", "validate", metrics.Fail))
+	assert.Equal(t, int64(2), r.Count("no-twos", "filter", metrics.Success))
+	assert.Equal(t, int64(1), r.Count("no-twos", "filter", metrics.Filtered))
+}
+
+func Test_Interceptor_Cancel(t *testing.T) {
+	t.Parallel()
+
+	r := metrics.NewRegistry()
+	ctx := rop.WithInterceptors(rop.WithStage(context.Background(), "check"), metrics.Interceptor(r))
+
+	solo.AndValidateCancelWithCtx(ctx, rop.Success(1), func(context.Context, int) bool { return false }, "stop")
+
+	assert.Equal(t, int64(1), r.Count("check", "validate", metrics.Cancel))
+}
+
+func Test_Interceptor_CountsCancelled(t *testing.T) {
+	t.Parallel()
+
+	r := metrics.NewRegistry()
+	ctx, cancel := context.WithCancel(rop.WithInterceptors(context.Background(), metrics.Interceptor(r)))
+	cancel()
+
+	for range mass.Map(ctx, mass.Successes(ctx, generate(3)),
+		func(_ context.Context, in int) int { return in }, cancelF[int], mass.Named("double")) {
+	}
+
+	assert.Equal(t, int64(3), r.Count("double", "cancel", metrics.Cancel))
+	assert.Zero(t, r.Count("double", "map", metrics.Success))
+}
+
+func Test_QueueDepth(t *testing.T) {
+	t.Parallel()
+
+	r := metrics.NewRegistry()
+	release := make(chan struct{})
+
+	inputs := make(chan rop.Result[int], 5)
+	for i := 0; i < 5; i++ {
+		inputs <- rop.Success(i)
+	}
+	close(inputs)
+
+	outputs := mass.Map(context.Background(), inputs,
+		func(_ context.Context, in int) int {
+			<-release
+			return in
+		}, cancelF[int], metrics.QueueDepth(r, "slow"))
+
+	assert.Eventually(t, func() bool {
+		return r.QueueDepth("slow") == 4
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	for range outputs {
+	}
+	assert.Equal(t, int64(0), r.QueueDepth("slow"))
+}
+
+func Test_QueueDepth_Chained(t *testing.T) {
+	t.Parallel()
+
+	r := metrics.NewRegistry()
+	release := make(chan struct{})
+
+	inputs := make(chan rop.Result[int], 5)
+	for i := 0; i < 5; i++ {
+		inputs <- rop.Success(i)
+	}
+	close(inputs)
+
+	outputs := mass.Map(context.Background(),
+		mass.Map(context.Background(), inputs,
+			func(_ context.Context, in int) int { return in }, cancelF[int], metrics.QueueDepth(r, "fast")),
+		func(_ context.Context, in int) int {
+			<-release
+			return in
+		}, cancelF[int], metrics.QueueDepth(r, "slow"))
+
+	// item 1 waits for the slow stage, 2 is stuck in the fast one, 3 waits
+	// for it and 4 is still buffered
+	assert.Eventually(t, func() bool {
+		return r.QueueDepth("slow") == 1 && r.QueueDepth("fast") == 2
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	for range outputs {
+	}
+	assert.Equal(t, int64(0), r.QueueDepth("slow"))
+	assert.Equal(t, int64(0), r.QueueDepth("fast"))
+}
+
+func Test_QueueDepth_SumsLanes(t *testing.T) {
+	t.Parallel()
+
+	r := metrics.NewRegistry()
+	release := make(chan struct{})
+
+	inputChs := make(chan chan rop.Result[int], 2)
+	for lane := 0; lane < 2; lane++ {
+		inputCh := make(chan rop.Result[int], 3)
+		for i := 0; i < 3; i++ {
+			inputCh <- rop.Success(i)
+		}
+		close(inputCh)
+		inputChs <- inputCh
+	}
+
+	outputs := bridge.Map(context.Background(), inputChs,
+		func(_ context.Context, in int) int {
+			<-release
+			return in
+		}, cancelF[int], metrics.QueueDepth(r, "lanes"))
+
+	assert.Eventually(t, func() bool {
+		return r.QueueDepth("lanes") == 4
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	var wg sync.WaitGroup
+	for _, output := range fan.ChsToSlice(outputs, 2) {
+		wg.Add(1)
+		go func(output chan rop.Result[int]) {
+			defer wg.Done()
+			for range output {
+			}
+		}(output)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(0), r.QueueDepth("lanes"))
+}
+
+func Test_Multi(t *testing.T) {
+	t.Parallel()
+
+	a, b := metrics.NewRegistry(), metrics.NewRegistry()
+	m := metrics.Multi(a, b)
+
+	m.Observe("s", "map", metrics.Fail, time.Millisecond)
+	m.AddQueueDepth("s", 2)
+
+	for _, r := range []*metrics.Registry{a, b} {
+		assert.Equal(t, int64(1), r.Count("s", "map", metrics.Fail))
+		assert.Equal(t, int64(2), r.QueueDepth("s"))
+	}
+	assert.Equal(t, metrics.Fail, metrics.StateOf(rop.Fail[int](errors.New("x"))))
+}
+
+func generate(n int) chan int {
+	inputs := make(chan int, n)
+	for i := 0; i < n; i++ {
+		inputs <- i
+	}
+	close(inputs)
+	return inputs
+}
+
+func cancelF[T any](_ context.Context, in T) error {
+	return errors.New("cancelled")
+}
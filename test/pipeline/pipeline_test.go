@@ -0,0 +1,224 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/fan"
+	"github.com/ib-77/rop/pkg/rop/pipeline"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+var errHundred = errors.New("value is 100")
+
+func cancelF[T any](_ context.Context, in T) error {
+	return fmt.Errorf("processing of %v was cancelled", in)
+}
+
+func checkout() pipeline.Pipeline[int, string] {
+	p := pipeline.New[int]()
+	p = pipeline.Validate(p, "positive",
+		func(_ context.Context, in int) bool { return in > 0 }, "not positive", cancelF[int])
+	p = pipeline.Filter(p, "odd",
+		func(_ context.Context, in int) bool { return in%2 != 0 }, cancelF[int])
+	p = pipeline.Switch(p, "hundred",
+		func(_ context.Context, in int) rop.Result[int] {
+			if in == 101 {
+				return rop.Fail[int](errHundred)
+			}
+			return rop.Success(in)
+		}, cancelF[int])
+	return pipeline.Map(p, "format",
+		func(_ context.Context, in int) string { return "#" + strconv.Itoa(in) }, cancelF[int])
+}
+
+func Test_Run(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	p := checkout()
+
+	assert.Equal(t, "#3", p.Run(ctx, 3).Result())
+	assert.True(t, p.Run(ctx, 4).IsFiltered())
+
+	res := p.Run(ctx, -1)
+	var stageErr *rop.StageError
+	assert.ErrorAs(t, res.Err(), &stageErr)
+	assert.Equal(t, "positive", stageErr.Stage)
+
+	res = p.Run(ctx, 101)
+	assert.ErrorIs(t, res.Err(), errHundred)
+	assert.ErrorAs(t, res.Err(), &stageErr)
+	assert.Equal(t, "hundred", stageErr.Stage)
+}
+
+func Test_Run_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res := checkout().Run(ctx, 3)
+
+	assert.True(t, res.IsCancel())
+	assert.EqualError(t, res.Err(), "processing of 3 was cancelled")
+}
+
+func Test_Run_RecoversPanic(t *testing.T) {
+	t.Parallel()
+
+	p := pipeline.Map(pipeline.New[int](), "divide",
+		func(_ context.Context, in int) int { return 10 / in }, cancelF[int])
+
+	var panicErr *rop.PanicError
+	assert.ErrorAs(t, p.Run(context.Background(), 0).Err(), &panicErr)
+}
+
+func Test_RunStream(t *testing.T) {
+	t.Parallel()
+
+	inputs := make(chan int, 6)
+	for _, v := range []int{-1, 1, 2, 3, 101, 5} {
+		inputs <- v
+	}
+	close(inputs)
+
+	var successes []string
+	var failures []error
+	filtered := 0
+	for res := range checkout().RunStream(context.Background(), inputs) {
+		switch {
+		case res.IsSuccess():
+			successes = append(successes, res.Result())
+		case res.IsFiltered():
+			filtered++
+		default:
+			failures = append(failures, res.Err())
+		}
+	}
+
+	assert.Equal(t, []string{"#1", "#3", "#5"}, successes)
+	assert.Equal(t, 1, filtered)
+	assert.Len(t, failures, 2)
+	assert.ErrorIs(t, failures[1], errHundred)
+}
+
+func Test_RunLanes(t *testing.T) {
+	t.Parallel()
+
+	inputChs := make(chan chan int, 2)
+	for _, lane := range [][]int{{1, 2, 3}, {5, 7}} {
+		inputCh := make(chan int, len(lane))
+		for _, v := range lane {
+			inputCh <- v
+		}
+		close(inputCh)
+		inputChs <- inputCh
+	}
+
+	outputs := checkout().RunLanes(context.Background(), inputChs)
+
+	var mu sync.Mutex
+	var results []string
+	var wg sync.WaitGroup
+	for _, output := range fan.ChsToSlice(outputs, 2) {
+		wg.Add(1)
+		go func(output chan rop.Result[string]) {
+			defer wg.Done()
+			for res := range output {
+				if res.IsSuccess() {
+					mu.Lock()
+					results = append(results, res.Result())
+					mu.Unlock()
+				}
+			}
+		}(output)
+	}
+	wg.Wait()
+
+	sort.Strings(results)
+	assert.Equal(t, []string{"#1", "#3", "#5", "#7"}, results)
+}
+
+func Test_Pipeline_SharedAcrossModes(t *testing.T) {
+	t.Parallel()
+
+	p := pipeline.Try(pipeline.New[string](), "parse",
+		func(_ context.Context, in string) (int, error) { return strconv.Atoi(in) }, cancelF[string])
+
+	ctx := context.Background()
+	inputs := make(chan string, 2)
+	inputs <- "1"
+	inputs <- "x"
+	close(inputs)
+
+	stream := make([]rop.Result[int], 0)
+	for res := range p.RunStream(ctx, inputs) {
+		stream = append(stream, res)
+	}
+
+	assert.Equal(t, p.Run(ctx, "1"), stream[0])
+	assert.Equal(t, p.Run(ctx, "x").Err().Error(), stream[1].Err().Error())
+}
+
+func Test_Lift(t *testing.T) {
+	t.Parallel()
+
+	var cancelled int
+	negate := rop.NewOperator(
+		func(_ context.Context, in rop.Result[int]) rop.Result[int] {
+			return rop.Success(-in.Result())
+		},
+		func(_ context.Context, in rop.Result[int]) rop.Result[int] {
+			cancelled++
+			return rop.Cancel[int](errors.New("negate cancelled"))
+		})
+
+	p := pipeline.Lift(pipeline.New[int](), "negate", negate)
+
+	assert.Equal(t, -2, p.Run(context.Background(), 2).Result())
+
+	inputs := make(chan int, 1)
+	inputs <- 3
+	close(inputs)
+	assert.Equal(t, -3, (<-p.RunStream(context.Background(), inputs)).Result())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.True(t, p.Run(ctx, 2).IsCancel())
+	assert.Equal(t, 1, cancelled)
+}
+
+func Test_Intercept(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var stages []string
+	record := func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+		mu.Lock()
+		stages = append(stages, call.Stage)
+		mu.Unlock()
+		return next(ctx)
+	}
+
+	p := pipeline.Validate(pipeline.New[int]().Intercept(record), "positive",
+		func(_ context.Context, in int) bool { return in > 0 }, "not positive", cancelF[int])
+	formatted := pipeline.Map(p, "format",
+		func(_ context.Context, in int) string { return strconv.Itoa(in) }, cancelF[int])
+
+	assert.Equal(t, "1", formatted.Run(context.Background(), 1).Result())
+	assert.Equal(t, []string{"positive", "format"}, stages)
+
+	stages = nil
+	inputs := make(chan int, 1)
+	inputs <- 2
+	close(inputs)
+	for range formatted.RunStream(context.Background(), inputs) {
+	}
+	assert.Equal(t, []string{"positive", "format"}, stages)
+}
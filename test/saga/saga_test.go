@@ -0,0 +1,158 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/saga"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var errDeclined = errors.New("card declined")
+
+type order struct {
+	log []string
+}
+
+func (o *order) reserveStock(_ context.Context, sku string) (string, error) {
+	o.log = append(o.log, "reserve "+sku)
+	return "reservation-1", nil
+}
+
+func (o *order) releaseStock(_ context.Context, reservation string) error {
+	o.log = append(o.log, "release "+reservation)
+	return nil
+}
+
+func (o *order) charge(_ context.Context, reservation string) (int, error) {
+	o.log = append(o.log, "charge for "+reservation)
+	return 100, nil
+}
+
+func (o *order) declineCharge(_ context.Context, reservation string) (int, error) {
+	return 0, errDeclined
+}
+
+func (o *order) refund(_ context.Context, amount int) error {
+	o.log = append(o.log, fmt.Sprintf("refund %d", amount))
+	return nil
+}
+
+func (o *order) bookShipping(_ context.Context, amount int) rop.Result[string] {
+	return rop.Fail[string](errors.New("no courier"))
+}
+
+func Test_Saga_Success(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	o := &order{}
+	s := saga.New()
+
+	result := saga.End(ctx, s,
+		saga.Step(ctx, s,
+			saga.Step(ctx, s, rop.Success("sku-1"), o.reserveStock, o.releaseStock),
+			o.charge, o.refund))
+
+	assert.Equal(t, rop.Success(100), result)
+	assert.Equal(t, []string{"reserve sku-1", "charge for reservation-1"}, o.log)
+}
+
+func Test_Saga_CompensatesInReverseOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	o := &order{}
+	s := saga.New()
+
+	result := saga.End(ctx, s,
+		saga.SwitchStep(ctx, s,
+			saga.Step(ctx, s,
+				saga.Step(ctx, s, rop.Success("sku-1"), o.reserveStock, o.releaseStock),
+				o.charge, o.refund),
+			o.bookShipping, nil))
+
+	assert.False(t, result.IsSuccess())
+	assert.False(t, result.IsCancel())
+	assert.Equal(t, "no courier", result.Err().Error())
+	assert.Equal(t, []string{"reserve sku-1", "charge for reservation-1",
+		"refund 100", "release reservation-1"}, o.log)
+}
+
+func Test_Saga_CollectsCompensationErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	o := &order{}
+	s := saga.New()
+	errRelease := errors.New("warehouse offline")
+
+	result := saga.End(ctx, s,
+		saga.Step(ctx, s,
+			saga.Step(ctx, s, rop.Success("sku-1"), o.reserveStock,
+				func(context.Context, string) error { return errRelease }),
+			o.declineCharge, o.refund))
+
+	assert.False(t, result.IsSuccess())
+	assert.ErrorIs(t, result.Err(), errDeclined)
+	assert.ErrorIs(t, result.Err(), errRelease)
+
+	var compErr *saga.CompensationError
+	assert.True(t, errors.As(result.Err(), &compErr))
+	assert.Equal(t, 1, compErr.Step)
+	assert.Equal(t, "card declined\ncompensate step 1: warehouse offline", result.Err().Error())
+}
+
+func Test_Saga_RecoversCompensationPanic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	o := &order{}
+	s := saga.New()
+
+	result := saga.End(ctx, s,
+		saga.SwitchStep(ctx, s,
+			saga.Step(ctx, s,
+				saga.Step(ctx, s, rop.Success("sku-1"), o.reserveStock, o.releaseStock),
+				o.charge, func(context.Context, int) error { panic("refund service gone") }),
+			o.bookShipping, nil))
+
+	assert.False(t, result.IsSuccess())
+	assert.Equal(t, []string{"reserve sku-1", "charge for reservation-1", "release reservation-1"}, o.log)
+
+	var compErr *saga.CompensationError
+	assert.True(t, errors.As(result.Err(), &compErr))
+	assert.Equal(t, 2, compErr.Step)
+
+	var panicErr *rop.PanicError
+	assert.True(t, errors.As(compErr, &panicErr))
+	assert.Equal(t, "refund service gone", panicErr.Value)
+}
+
+func Test_Saga_CompensatesOnCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	o := &order{}
+	s := saga.New()
+
+	reserved := saga.Step(ctx, s, rop.Success("sku-1"), o.reserveStock,
+		func(ctx context.Context, r string) error {
+			assert.NoError(t, ctx.Err()) // compensation must not see the cancellation
+			return o.releaseStock(ctx, r)
+		})
+	cancel()
+
+	result := saga.End(ctx, s,
+		saga.Step(ctx, s, reserved, func(ctx context.Context, r string) (int, error) {
+			return 0, ctx.Err()
+		}, o.refund))
+
+	assert.False(t, result.IsSuccess())
+	assert.Equal(t, []string{"reserve sku-1", "release reservation-1"}, o.log)
+
+	result = saga.End(ctx, saga.New(), rop.Cancel[int](context.Canceled))
+	assert.True(t, result.IsCancel())
+}
@@ -0,0 +1,155 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type conn struct {
+	closed int
+}
+
+func closeConn(_ context.Context, c *conn) error {
+	c.closed++
+	return nil
+}
+
+func Test_Bracket_ReleasesOnEveryTrack(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	c := &conn{}
+	result := solo.Bracket(ctx, rop.Success(c), closeConn,
+		func(_ context.Context, c *conn) rop.Result[int] {
+			return rop.Success(1)
+		})
+	assert.Equal(t, rop.Success(1), result)
+	assert.Equal(t, 1, c.closed)
+
+	c = &conn{}
+	result = solo.Bracket(ctx, rop.Success(c), closeConn,
+		func(_ context.Context, c *conn) rop.Result[int] {
+			return rop.Fail[int](errors.New("query"))
+		})
+	assert.Equal(t, rop.Fail[int](errors.New("query")), result)
+	assert.Equal(t, 1, c.closed)
+
+	c = &conn{}
+	result = solo.Bracket(ctx, rop.Success(c), closeConn,
+		func(_ context.Context, c *conn) rop.Result[int] {
+			return rop.Cancel[int](errors.New("stop"))
+		})
+	assert.Equal(t, rop.Cancel[int](errors.New("stop")), result)
+	assert.Equal(t, 1, c.closed)
+}
+
+func Test_Bracket_ReleasesOnPanic(t *testing.T) {
+	t.Parallel()
+
+	c := &conn{}
+	assert.PanicsWithValue(t, "boom", func() {
+		solo.Bracket(context.Background(), rop.Success(c), closeConn,
+			func(_ context.Context, c *conn) rop.Result[int] {
+				panic("boom")
+			})
+	})
+	assert.Equal(t, 1, c.closed)
+}
+
+func Test_Bracket_ReleasesWithCancelledCtx(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancelF := context.WithCancel(context.Background())
+	cancelF()
+
+	var releaseErr error
+	solo.Bracket(ctx, rop.Success(&conn{}),
+		func(ctx context.Context, c *conn) error {
+			releaseErr = ctx.Err()
+			return nil
+		},
+		func(ctx context.Context, c *conn) rop.Result[int] {
+			return rop.Cancel[int](ctx.Err())
+		})
+	assert.NoError(t, releaseErr)
+}
+
+func Test_Bracket_MergesReleaseError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	errRelease := errors.New("release")
+	failRelease := func(_ context.Context, c *conn) error { return errRelease }
+
+	result := solo.Bracket(ctx, rop.Success(&conn{}), failRelease,
+		func(_ context.Context, c *conn) rop.Result[int] {
+			return rop.Success(1)
+		})
+	assert.Equal(t, rop.Fail[int](errRelease), result)
+
+	errQuery := errors.New("query")
+	result = solo.Bracket(ctx, rop.Success(&conn{}), failRelease,
+		func(_ context.Context, c *conn) rop.Result[int] {
+			return rop.Fail[int](errQuery)
+		})
+	assert.False(t, result.IsSuccess())
+	assert.False(t, result.IsCancel())
+	assert.ErrorIs(t, result.Err(), errQuery)
+	assert.ErrorIs(t, result.Err(), errRelease)
+
+	result = solo.Bracket(ctx, rop.Success(&conn{}), failRelease,
+		func(_ context.Context, c *conn) rop.Result[int] {
+			return rop.Cancel[int](errQuery)
+		})
+	assert.True(t, result.IsCancel())
+	assert.ErrorIs(t, result.Err(), errRelease)
+}
+
+func Test_Bracket_SkipsFailedAcquire(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	result := solo.Bracket(context.Background(), rop.Fail[*conn](errors.New("dial")),
+		func(_ context.Context, c *conn) error {
+			called = true
+			return nil
+		},
+		func(_ context.Context, c *conn) rop.Result[int] {
+			called = true
+			return rop.Success(1)
+		})
+
+	assert.Equal(t, rop.Fail[int](errors.New("dial")), result)
+	assert.False(t, called)
+}
+
+func Test_Using(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	opened := make([]*conn, 0)
+	open := func(_ context.Context, addr string) (*conn, error) {
+		if addr == "" {
+			return nil, errors.New("no address")
+		}
+		c := &conn{}
+		opened = append(opened, c)
+		return c, nil
+	}
+	query := func(_ context.Context, addr string, c *conn) rop.Result[string] {
+		return rop.Success("rows from " + addr)
+	}
+
+	assert.Equal(t, rop.Success("rows from db"),
+		solo.Using(ctx, rop.Success("db"), open, closeConn, query))
+	assert.Equal(t, rop.Fail[string](errors.New("no address")),
+		solo.Using(ctx, rop.Success(""), open, closeConn, query))
+
+	assert.Len(t, opened, 1)
+	assert.Equal(t, 1, opened[0].closed)
+}
@@ -0,0 +1,78 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func isEven(_ context.Context, r int) bool {
+	return r%2 == 0
+}
+
+func Test_Filter(t *testing.T) {
+	t.Parallel()
+
+	kept := solo.Filter(rop.Success(2), func(r int) bool { return r%2 == 0 })
+	assert.Equal(t, rop.Success(2), kept)
+
+	filtered := solo.Filter(rop.Success(3), func(r int) bool { return r%2 == 0 })
+	assert.True(t, filtered.IsFiltered())
+	assert.False(t, filtered.IsSuccess())
+	assert.False(t, filtered.IsCancel())
+	assert.ErrorIs(t, filtered.Err(), solo.ErrFiltered)
+
+	failed := rop.Fail[int](errors.New("fail"))
+	assert.Equal(t, failed, solo.Filter(failed, func(r int) bool { return false }))
+}
+
+func Test_Skip_PassesStagesUntouched(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	called := false
+
+	filtered := solo.FilterWithCtx(ctx, rop.Success(3), isEven)
+	result := solo.TryWithCtx(ctx,
+		solo.MapWithCtx(ctx, filtered, func(_ context.Context, r int) int {
+			called = true
+			return r
+		}),
+		func(_ context.Context, r int) (string, error) {
+			called = true
+			return "", nil
+		})
+
+	assert.False(t, called)
+	assert.True(t, result.IsFiltered())
+	assert.ErrorIs(t, result.Err(), solo.ErrFiltered)
+
+	recovered := solo.Recover(result, func(err error) string { return "recovered" }, nil)
+	assert.True(t, recovered.IsFiltered())
+}
+
+func Test_Skip_NeverReachesFinallyHandlers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	filtered := solo.FilterWithCtx(ctx, rop.Success(3), isEven)
+
+	out := solo.FinallyWithCtx(ctx, filtered,
+		func(_ context.Context, r int) string {
+			return "success"
+		},
+		func(_ context.Context, err error) string {
+			return "fail"
+		})
+	assert.Equal(t, "", out)
+
+	handled := false
+	solo.DoubleMapWithCtx(ctx, filtered,
+		func(_ context.Context, r int) int { return r },
+		func(_ context.Context, err error) int { handled = true; return 0 },
+		func(_ context.Context, err error) int { handled = true; return 0 })
+	assert.False(t, handled)
+}
@@ -0,0 +1,105 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func Test_Interceptors_AroundUserFunctions(t *testing.T) {
+	t.Parallel()
+
+	var kinds []string
+	ctx := rop.WithInterceptors(context.Background(),
+		func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+			kinds = append(kinds, call.Kind)
+			return next(ctx)
+		})
+
+	result := solo.TryWithCtx(ctx,
+		solo.MapWithCtx(ctx,
+			solo.TeeWithCtx(ctx,
+				solo.AndValidateWithCtx(ctx,
+					solo.ValidateWithCtx(ctx, 5, func(_ context.Context, in int) bool { return in > 0 }, "negative"),
+					func(_ context.Context, in int) bool { return in < 10 }, "too big"),
+				func(context.Context, rop.Result[int]) {}),
+			func(_ context.Context, in int) string { return strconv.Itoa(in) }),
+		func(_ context.Context, in string) (int, error) { return strconv.Atoi(in + "0") })
+
+	assert.Equal(t, rop.Success(50), result)
+	assert.Equal(t, []string{"validate", "validate", "tee", "map", "try"}, kinds)
+}
+
+func Test_Interceptors_NotCalledOnPassthrough(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	ctx := rop.WithInterceptors(context.Background(),
+		func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+			calls++
+			return next(ctx)
+		})
+
+	solo.MapWithCtx(ctx, rop.Fail[int](errors.New("fail")), func(_ context.Context, in int) int { return in })
+
+	assert.Zero(t, calls)
+}
+
+func Test_Interceptors_CapturePanic(t *testing.T) {
+	t.Parallel()
+
+	ctx := rop.WithInterceptors(context.Background(),
+		func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) (out rop.Outcome) {
+			defer func() {
+				if v := recover(); v != nil {
+					out = rop.Fail[any](rop.NewPanicError(v))
+				}
+			}()
+			return next(ctx)
+		})
+
+	result := solo.MapWithCtx(ctx, rop.Success(0), func(_ context.Context, in int) int { return 1 / in })
+
+	var panicErr *rop.PanicError
+	assert.ErrorAs(t, result.Err(), &panicErr)
+}
+
+func Test_Interceptors_FinallyAndCancel(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	ctx := rop.WithInterceptors(context.Background(),
+		func(ctx context.Context, call rop.Call, next func(ctx context.Context) rop.Outcome) rop.Outcome {
+			out := next(ctx)
+			calls = append(calls, call.Kind+":"+strconv.FormatBool(out.IsCancel()))
+			return out
+		})
+
+	handle := func(context.Context, error) string { return "handled" }
+	failed := rop.Fail[int](errors.New("fail"))
+	cancelled := rop.Cancel[int](errors.New("cancel"))
+
+	assert.Equal(t, "handled", solo.FinallyWithCtx(ctx, failed,
+		func(context.Context, int) string { return "" }, handle))
+	_, err := solo.FinallyWithCtxWithErr(ctx, rop.Success(1),
+		func(context.Context, int) (string, error) { return "", errors.New("flush") },
+		func(context.Context, error) (string, error) { return "", nil })
+	assert.EqualError(t, err, "flush")
+	solo.FinallyTeeWithCtx(ctx, cancelled, func(context.Context, int) {}, func(context.Context, error) {})
+
+	solo.DoubleMapWithCtx(ctx, cancelled,
+		func(context.Context, int) string { return "" }, handle, handle)
+	solo.DoubleTeeWithCtx(ctx, failed, func(context.Context, int) {}, func(context.Context, error) {})
+	solo.CancelWithCtx[int, string](ctx, rop.Success(1), func(context.Context, int) error {
+		return errors.New("stopped")
+	})
+	solo.FinallyWithCtx(ctx, rop.Filtered[int](solo.ErrFiltered),
+		func(context.Context, int) string { return "" }, handle)
+
+	assert.Equal(t, []string{"finally:false", "finally:false", "finally:true",
+		"map:true", "tee:false", "cancel:true"}, calls)
+}
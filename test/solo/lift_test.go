@@ -0,0 +1,29 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func Test_Lift(t *testing.T) {
+	t.Parallel()
+
+	format := rop.NewOperator(
+		func(_ context.Context, in rop.Result[int]) rop.Result[string] {
+			return rop.Success(strconv.Itoa(in.Result()))
+		},
+		func(_ context.Context, in rop.Result[int]) rop.Result[string] {
+			return rop.Cancel[string](errors.New("format cancelled"))
+		})
+
+	assert.Equal(t, rop.Success("7"), solo.Lift(context.Background(), rop.Success(7), format))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, rop.Cancel[string](errors.New("format cancelled")), solo.Lift(ctx, rop.Success(7), format))
+}
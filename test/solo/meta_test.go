@@ -0,0 +1,73 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+var tenantKey = rop.NewKey[string]("tenant")
+
+func Test_Meta_ThroughChain(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	input := rop.SetMeta(rop.Success(21), tenantKey, "acme")
+
+	seen := make([]string, 0)
+	seeTenant := func(ctx context.Context) {
+		tenant, _ := rop.GetMetaFromCtx(ctx, tenantKey)
+		seen = append(seen, tenant)
+	}
+
+	result := solo.TryWithCtx(ctx,
+		solo.SwitchWithCtx(ctx,
+			solo.MapWithCtx(ctx, input, func(ctx context.Context, r int) int {
+				seeTenant(ctx)
+				return r * 2
+			}),
+			func(ctx context.Context, r int) rop.Result[string] {
+				seeTenant(ctx)
+				return rop.Success(strconv.Itoa(r))
+			}),
+		func(ctx context.Context, r string) (int, error) {
+			seeTenant(ctx)
+			return 0, errors.New("declined")
+		})
+
+	tenant, ok := rop.GetMeta(result, tenantKey)
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tenant)
+	assert.Equal(t, []string{"acme", "acme", "acme"}, seen)
+
+	report := solo.FinallyWithCtx(ctx, result,
+		func(_ context.Context, r int) string {
+			return "ok"
+		},
+		func(ctx context.Context, err error) string {
+			tenant, _ := rop.GetMetaFromCtx(ctx, tenantKey)
+			return tenant + ": " + err.Error()
+		})
+	assert.Equal(t, "acme: declined", report)
+}
+
+func Test_Meta_SwitchKeepsOwnEntries(t *testing.T) {
+	t.Parallel()
+
+	attemptKey := rop.NewKey[int]("attempt")
+	input := rop.SetMeta(rop.Success(1), tenantKey, "acme")
+
+	result := solo.SwitchWithCtx(context.Background(), input,
+		func(_ context.Context, r int) rop.Result[int] {
+			return rop.SetMeta(rop.Success(r), attemptKey, 2)
+		})
+
+	tenant, _ := rop.GetMeta(result, tenantKey)
+	attempt, _ := rop.GetMeta(result, attemptKey)
+	assert.Equal(t, "acme", tenant)
+	assert.Equal(t, 2, attempt)
+}
@@ -0,0 +1,57 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_Protect(t *testing.T) {
+	t.Parallel()
+
+	result := solo.Protect(func() rop.Result[int] {
+		var m map[string]int
+		m["boom"] = 1
+		return rop.Success(1)
+	})
+
+	assert.False(t, result.IsSuccess())
+	assert.False(t, result.IsCancel())
+
+	var panicErr *rop.PanicError
+	assert.ErrorAs(t, result.Err(), &panicErr)
+	assert.Contains(t, string(panicErr.Stack), "protect_test.go")
+
+	assert.Equal(t, rop.Success(2), solo.Protect(func() rop.Result[int] {
+		return rop.Success(2)
+	}))
+}
+
+func Test_Protect_UnwrapsErrorValue(t *testing.T) {
+	t.Parallel()
+
+	errBoom := errors.New("boom")
+	result := solo.Protect(func() rop.Result[int] {
+		panic(errBoom)
+	})
+
+	assert.ErrorIs(t, result.Err(), errBoom)
+	assert.Equal(t, "panic: boom", result.Err().Error())
+}
+
+func Test_TimeoutWithCtx_RecoversPanic(t *testing.T) {
+	t.Parallel()
+
+	result := solo.TimeoutWithCtx(context.Background(), rop.Success(1), time.Second, solo.TimeoutFail,
+		func(_ context.Context, r int) rop.Result[int] {
+			panic("boom")
+		})
+
+	var panicErr *rop.PanicError
+	assert.ErrorAs(t, result.Err(), &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+}
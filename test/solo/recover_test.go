@@ -0,0 +1,85 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var errNotFound = errors.New("not found")
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string { return "timeout" }
+
+func Test_Recover(t *testing.T) {
+	t.Parallel()
+
+	failed := rop.Fail[int](fmt.Errorf("load user: %w", errNotFound))
+	toZero := func(err error) int { return 0 }
+
+	assert.Equal(t, rop.Success(0), solo.Recover(failed, toZero, nil))
+	assert.Equal(t, rop.Success(0), solo.Recover(failed, toZero, solo.ErrorIs(errNotFound)))
+	assert.Equal(t, failed, solo.Recover(failed, toZero, solo.ErrorAs[timeoutErr]()))
+	assert.Equal(t, rop.Success(7), solo.Recover(rop.Success(7), toZero, nil))
+
+	cancelled := rop.Cancel[int](errNotFound)
+	assert.Equal(t, cancelled, solo.Recover(cancelled, toZero, nil))
+}
+
+func Test_RecoverWithCtx(t *testing.T) {
+	t.Parallel()
+
+	result := solo.RecoverWithCtx(context.Background(), rop.Fail[string](timeoutErr{}),
+		func(_ context.Context, err error) string {
+			return "recovered from " + err.Error()
+		}, solo.ErrorAs[timeoutErr]())
+
+	assert.Equal(t, rop.Success("recovered from timeout"), result)
+}
+
+func Test_RecoverWith(t *testing.T) {
+	t.Parallel()
+
+	failed := rop.Fail[int](errNotFound)
+
+	fromCache := func(err error) rop.Result[int] { return rop.Success(42) }
+	assert.Equal(t, rop.Success(42), solo.RecoverWith(failed, fromCache, solo.ErrorIs(errNotFound)))
+
+	cacheMiss := func(err error) rop.Result[int] { return rop.Fail[int](errors.Join(err, errors.New("cache miss"))) }
+	result := solo.RecoverWith(failed, cacheMiss, nil)
+	assert.False(t, result.IsSuccess())
+	assert.ErrorIs(t, result.Err(), errNotFound)
+
+	result = solo.RecoverWithWithCtx(context.Background(), failed,
+		func(_ context.Context, err error) rop.Result[int] { return rop.Success(1) }, nil)
+	assert.Equal(t, rop.Success(1), result)
+}
+
+func Test_OrElse(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, rop.Success("default"),
+		solo.OrElse(rop.Fail[string](errNotFound), "default", solo.ErrorIs(errNotFound)))
+	assert.Equal(t, rop.Success("value"),
+		solo.OrElse(rop.Success("value"), "default", nil))
+	assert.Equal(t, rop.Fail[string](timeoutErr{}),
+		solo.OrElse(rop.Fail[string](timeoutErr{}), "default", solo.ErrorIs(errNotFound)))
+}
+
+func Test_FailToCancelAndBack(t *testing.T) {
+	t.Parallel()
+
+	cancelled := solo.FailToCancel(rop.Fail[int](timeoutErr{}), solo.ErrorAs[timeoutErr]())
+	assert.Equal(t, rop.Cancel[int](timeoutErr{}), cancelled)
+
+	kept := solo.FailToCancel(rop.Fail[int](errNotFound), solo.ErrorAs[timeoutErr]())
+	assert.Equal(t, rop.Fail[int](errNotFound), kept)
+
+	assert.Equal(t, rop.Fail[int](timeoutErr{}), solo.CancelToFail(cancelled, nil))
+	assert.Equal(t, rop.Success(1), solo.CancelToFail(rop.Success(1), nil))
+}
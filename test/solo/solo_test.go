@@ -6,6 +6,7 @@ import (
 	"github.com/ib-77/rop/pkg/rop"
 	"github.com/ib-77/rop/pkg/rop/solo"
 	"github.com/ib-77/rop/test"
+	"github.com/ib-77/rop/test/leak"
 	"github.com/stretchr/testify/assert"
 	"os"
 	"strconv"
@@ -21,7 +22,7 @@ func TestMain(t *testing.M) {
 	setupAll()
 	code := t.Run()
 	tearDownAll()
-	os.Exit(code)
+	os.Exit(leak.CheckMain(code))
 }
 
 func setupAll() {
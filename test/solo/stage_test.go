@@ -0,0 +1,72 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+var errDeclined = errors.New("card declined")
+
+func Test_StageTrail_ThroughChain(t *testing.T) {
+	t.Parallel()
+
+	ctx := rop.WithTrail(context.Background())
+
+	charged := solo.TryWithCtx(rop.WithStage(ctx, "charge"),
+		solo.SwitchWithCtx(ctx,
+			solo.ValidateWithCtx(ctx, 42, func(_ context.Context, in int) bool {
+				return in > 0
+			}, "not positive"),
+			func(_ context.Context, r int) rop.Result[string] {
+				return rop.Success(strconv.Itoa(r))
+			}),
+		func(_ context.Context, r string) (int, error) {
+			return 0, errDeclined
+		})
+
+	assert.Equal(t, "validate→switch→try(charge): card declined", charged.Breadcrumb())
+	assert.ErrorIs(t, charged.Err(), errDeclined)
+
+	var stageErr *rop.StageError
+	assert.ErrorAs(t, charged.Err(), &stageErr)
+	assert.Equal(t, "charge", stageErr.Stage)
+
+	// stages after the failure are skipped and are not in the trail
+	mapped := solo.MapWithCtx(ctx, charged, func(_ context.Context, r int) int { return r })
+	assert.Equal(t, charged.Trail(), mapped.Trail())
+}
+
+func Test_StageTrail_ReachesFinally(t *testing.T) {
+	t.Parallel()
+
+	ctx := rop.WithTrail(context.Background())
+	failed := solo.TryWithCtx(rop.WithStage(ctx, "charge"), rop.Success(1),
+		func(_ context.Context, r int) (int, error) {
+			return 0, errDeclined
+		})
+
+	report := solo.FinallyWithCtx(ctx, failed,
+		func(_ context.Context, r int) string {
+			return "ok"
+		},
+		func(ctx context.Context, err error) string {
+			return rop.Breadcrumb(rop.GetTrailFromCtx(ctx), err)
+		})
+
+	assert.Equal(t, "try(charge): card declined", report)
+}
+
+func Test_StageTrail_OffByDefault(t *testing.T) {
+	t.Parallel()
+
+	result := solo.MapWithCtx(context.Background(), rop.Success(1),
+		func(_ context.Context, r int) int { return r + 1 })
+
+	assert.Equal(t, rop.Success(2), result)
+	assert.Empty(t, result.Trail())
+}
@@ -0,0 +1,133 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_TimeoutWithCtx_InTime(t *testing.T) {
+	t.Parallel()
+
+	result := solo.TimeoutWithCtx(context.Background(), rop.Success(2), time.Second,
+		solo.TimeoutFail, sleepAndDouble(time.Millisecond))
+
+	assert.Equal(t, rop.Success(4), result)
+}
+
+func Test_TimeoutWithCtx_Fail(t *testing.T) {
+	t.Parallel()
+
+	result := solo.TimeoutWithCtx(context.Background(), rop.Success(2), 10*time.Millisecond,
+		solo.TimeoutFail, sleepAndDouble(time.Hour))
+
+	assert.False(t, result.IsSuccess())
+	assert.False(t, result.IsCancel())
+	assert.ErrorIs(t, result.Err(), solo.ErrStepTimeout)
+	assert.Equal(t, "step timed out after 10ms", result.Err().Error())
+}
+
+func Test_TimeoutWithCtx_Cancel(t *testing.T) {
+	t.Parallel()
+
+	result := solo.TimeoutWithCtx(context.Background(), rop.Success(2), 10*time.Millisecond,
+		solo.TimeoutCancel, sleepAndDouble(time.Hour))
+
+	assert.True(t, result.IsCancel())
+	assert.ErrorIs(t, result.Err(), solo.ErrStepTimeout)
+}
+
+func Test_TimeoutWithCtx_ParentCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	result := solo.TimeoutWithCtx(ctx, rop.Success(2), time.Hour,
+		solo.TimeoutFail, sleepAndDouble(time.Hour))
+
+	assert.True(t, result.IsCancel())
+	assert.ErrorIs(t, result.Err(), context.Canceled)
+}
+
+func Test_TimeoutWithCtx_PassFail(t *testing.T) {
+	t.Parallel()
+
+	result := solo.TimeoutWithCtx(context.Background(), rop.Fail[int](errors.New("fail")),
+		time.Second, solo.TimeoutFail, sleepAndDouble(0))
+
+	assert.Equal(t, rop.Fail[int](errors.New("fail")), result)
+}
+
+func Test_SplitBudget(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, time.Second},
+		solo.SplitBudget(4*time.Second, 1, 2, 1))
+	assert.Equal(t, []time.Duration{0, 0}, solo.SplitBudget(time.Second, 0, 0))
+	assert.Equal(t, []time.Duration{0}, solo.SplitDeadline(context.Background(), 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	parts := solo.SplitDeadline(ctx, 1, 1)
+	assert.LessOrEqual(t, parts[0], 500*time.Millisecond)
+	assert.Greater(t, parts[0], 400*time.Millisecond)
+	assert.Equal(t, parts[0], parts[1])
+}
+
+func Test_SplitBudget_Exhausted(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []time.Duration{solo.Exhausted, solo.Exhausted}, solo.SplitBudget(0, 1, 1))
+	assert.Equal(t, []time.Duration{solo.Exhausted, 2}, solo.SplitBudget(2, 0, 1))
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	parts := solo.SplitDeadline(ctx, 1, 1)
+	assert.Equal(t, []time.Duration{solo.Exhausted, solo.Exhausted}, parts)
+
+	result := solo.TimeoutWithCtx(context.Background(), rop.Success(2), parts[0],
+		solo.TimeoutFail, sleepAndDouble(0))
+	assert.ErrorIs(t, result.Err(), solo.ErrStepTimeout)
+	assert.Equal(t, "step timed out: no time left", result.Err().Error())
+
+	result = solo.TimeoutWithCtx(ctx, rop.Success(2), parts[0],
+		solo.TimeoutFail, sleepAndDouble(0))
+	assert.True(t, result.IsCancel())
+	assert.ErrorIs(t, result.Err(), context.DeadlineExceeded)
+}
+
+func sleepAndDouble(d time.Duration) func(ctx context.Context, r int) rop.Result[int] {
+	return func(ctx context.Context, r int) rop.Result[int] {
+		select {
+		case <-time.After(d):
+			return rop.Success(r * 2)
+		case <-ctx.Done():
+			return rop.Fail[int](ctx.Err())
+		}
+	}
+}
+
+func Test_TimeoutWithCtx_CancelReason(t *testing.T) {
+	t.Parallel()
+
+	result := solo.TimeoutWithCtx(context.Background(), rop.Success(1), time.Millisecond,
+		solo.TimeoutCancel, sleepAndDouble(time.Hour))
+	assert.True(t, result.IsDeadline())
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(rop.ErrShutdown)
+
+	result = solo.TimeoutWithCtx(ctx, rop.Success(1), time.Second,
+		solo.TimeoutFail, sleepAndDouble(time.Hour))
+	assert.True(t, result.IsShutdown())
+}
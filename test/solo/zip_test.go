@@ -0,0 +1,130 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type user struct{ name string }
+type account struct{ balance int }
+
+func Test_Zip2(t *testing.T) {
+	t.Parallel()
+
+	zipped := solo.Zip2(rop.Success(user{"ann"}), rop.Success(account{10}))
+	assert.Equal(t, rop.Success(rop.Tuple2[user, account]{V1: user{"ann"}, V2: account{10}}), zipped)
+
+	failed := solo.Zip2(rop.Success(user{"ann"}), rop.Fail[account](errors.New("no account")))
+	assert.Equal(t, rop.Fail[rop.Tuple2[user, account]](errors.New("no account")), failed)
+
+	cancelled := solo.Zip2(rop.Cancel[user](errors.New("stop")), rop.Fail[account](errors.New("no account")))
+	assert.Equal(t, rop.Cancel[rop.Tuple2[user, account]](errors.New("stop")), cancelled)
+}
+
+func Test_Zip5(t *testing.T) {
+	t.Parallel()
+
+	zipped := solo.Zip5(rop.Success(1), rop.Success("2"), rop.Success(3.0),
+		rop.Success(true), rop.Success(user{"ann"}))
+	assert.True(t, zipped.IsSuccess())
+	assert.Equal(t, rop.Tuple5[int, string, float64, bool, user]{
+		V1: 1, V2: "2", V3: 3.0, V4: true, V5: user{"ann"}}, zipped.Result())
+
+	failed := solo.Zip4(rop.Success(1), rop.Success(2), rop.Fail[int](errors.New("third")),
+		rop.Fail[int](errors.New("fourth")))
+	assert.Equal(t, "third", failed.Err().Error())
+}
+
+func Test_Zip_KeepsMeta(t *testing.T) {
+	t.Parallel()
+
+	zipped := solo.Zip3(rop.SetMeta(rop.Success(1), tenantKey, "acme"), rop.Success(2), rop.Success(3))
+
+	tenant, ok := rop.GetMeta(zipped, tenantKey)
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tenant)
+}
+
+func Test_Sequence(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, rop.Success([]int{1, 2, 3}),
+		solo.Sequence([]rop.Result[int]{rop.Success(1), rop.Success(2), rop.Success(3)}))
+	assert.Equal(t, rop.Success([]int{}), solo.Sequence[int](nil))
+	assert.Equal(t, rop.Success([]int{1}),
+		solo.Sequence([]rop.Result[int]{rop.Success(1), rop.Filtered[int](solo.ErrFiltered)}))
+	assert.Equal(t, rop.Fail[[]int](errors.New("second")),
+		solo.Sequence([]rop.Result[int]{rop.Success(1), rop.Fail[int](errors.New("second")),
+			rop.Fail[int](errors.New("third"))}))
+}
+
+func Test_Traverse_FailFast(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	result := solo.Traverse([]int{1, 2, 3, 4}, func(r int) rop.Result[string] {
+		calls++
+		if r%2 == 0 {
+			return rop.Fail[string](fmt.Errorf("even %d", r))
+		}
+		return rop.Success(fmt.Sprint(r))
+	}, solo.FailFast)
+
+	assert.Equal(t, rop.Fail[[]string](errors.New("even 2")), result)
+	assert.Equal(t, 2, calls)
+}
+
+func Test_Traverse_CollectAll(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	result := solo.TraverseWithCtx(context.Background(), []int{1, 2, 3, 4},
+		func(_ context.Context, r int) rop.Result[string] {
+			calls++
+			switch r {
+			case 2:
+				return rop.Fail[string](fmt.Errorf("even %d", r))
+			case 4:
+				return rop.Cancel[string](fmt.Errorf("cancelled %d", r))
+			}
+			return rop.Success(fmt.Sprint(r))
+		}, solo.CollectAll)
+
+	assert.Equal(t, 4, calls)
+	assert.False(t, result.IsSuccess())
+	assert.False(t, result.IsCancel())
+	assert.Equal(t, "even 2\ncancelled 4", result.Err().Error())
+
+	cancelled := solo.Traverse([]int{1}, func(r int) rop.Result[int] {
+		return rop.Cancel[int](errors.New("stop"))
+	}, solo.CollectAll)
+	assert.True(t, cancelled.IsCancel())
+
+	all := solo.Traverse([]int{1, 2}, func(r int) rop.Result[int] {
+		return rop.Success(r * 10)
+	}, solo.CollectAll)
+	assert.Equal(t, rop.Success([]int{10, 20}), all)
+}
+
+func Test_Partition(t *testing.T) {
+	t.Parallel()
+
+	successes, failures := solo.Partition([]rop.Result[int]{
+		rop.Success(1),
+		rop.Fail[int](errors.New("fail")),
+		rop.Filtered[int](solo.ErrFiltered),
+		rop.Success(2),
+		rop.Cancel[int](errors.New("cancel")),
+	})
+
+	assert.Equal(t, []int{1, 2}, successes)
+	assert.Equal(t, []rop.Result[int]{
+		rop.Fail[int](errors.New("fail")),
+		rop.Cancel[int](errors.New("cancel")),
+	}, failures)
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_Step_UnnamedKeepsResultUnchanged(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	assert.Equal(t, rop.Success(1), rop.Step(ctx, "map", rop.Success(0), rop.Success(1)))
+	assert.Equal(t, rop.Fail[int](errors.New("fail")),
+		rop.Step(ctx, "try", rop.Success(0), rop.Fail[int](errors.New("fail"))))
+}
+
+func Test_Step_NamedWrapsError(t *testing.T) {
+	t.Parallel()
+
+	errDeclined := errors.New("card declined")
+	ctx := rop.WithStage(context.Background(), "charge")
+
+	result := rop.Step(ctx, "try", rop.Success(0), rop.Fail[int](errDeclined))
+
+	var stageErr *rop.StageError
+	assert.ErrorAs(t, result.Err(), &stageErr)
+	assert.Equal(t, "charge", stageErr.Stage)
+	assert.ErrorIs(t, result.Err(), errDeclined)
+	assert.Equal(t, "charge: card declined", result.Err().Error())
+	assert.Equal(t, []string{"try(charge)"}, result.Trail())
+}
+
+func Test_Breadcrumb(t *testing.T) {
+	t.Parallel()
+
+	ctx := rop.WithTrail(context.Background())
+
+	validated := rop.Step(ctx, "validate", rop.Success(1), rop.Success(1))
+	switched := rop.Step(ctx, "switch", validated, rop.Success("1"))
+	charged := rop.Step(rop.WithStage(ctx, "charge"), "try", switched,
+		rop.Fail[int](errors.New("card declined")))
+
+	assert.Equal(t, "validate→switch", switched.Breadcrumb())
+	assert.Equal(t, "validate→switch→try(charge): card declined", charged.Breadcrumb())
+	assert.Equal(t, []string{"validate"}, validated.Trail())
+}
+
+func Test_Carry(t *testing.T) {
+	t.Parallel()
+
+	ctx := rop.WithTrail(context.Background())
+	from := rop.Step(ctx, "map", rop.Success(1), rop.Success(2))
+	own := rop.Step(ctx, "validate", rop.Success(3), rop.Success(3))
+
+	assert.Equal(t, []string{"map"}, rop.Carry(from, rop.Success("x")).Trail())
+	assert.Equal(t, []string{"validate"}, rop.Carry(from, own).Trail())
+	assert.Nil(t, rop.Carry(rop.Success(1), rop.Success("x")).Trail())
+}
+
+func Test_GetTrailFromCtx(t *testing.T) {
+	t.Parallel()
+
+	ctx := rop.WithTrail(context.Background())
+	result := rop.Step(ctx, "map", rop.Success(1), rop.Success(2))
+
+	assert.Equal(t, []string{"map"}, rop.GetTrailFromCtx(rop.WithResultOf(ctx, result)))
+	assert.Empty(t, rop.GetTrailFromCtx(ctx))
+}
+
+func Test_Step_RecordsStageOnce(t *testing.T) {
+	t.Parallel()
+
+	ctx := rop.WithStage(context.Background(), "checks")
+
+	inner := rop.Step(ctx, "andtee", rop.Success(0), rop.Fail[int](errors.New("kyc")))
+	outer := rop.Step(ctx, "andtee", rop.Success(0), inner)
+
+	assert.Equal(t, []string{"andtee(checks)"}, outer.Trail())
+	assert.Equal(t, "checks: kyc", outer.Err().Error())
+
+	again := rop.Step(ctx, "andtee", outer, rop.Success(1))
+	assert.Equal(t, []string{"andtee(checks)", "andtee(checks)"}, again.Trail())
+}
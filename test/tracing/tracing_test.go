@@ -0,0 +1,186 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/ib-77/rop/pkg/rop"
+	"github.com/ib-77/rop/pkg/rop/mass"
+	"github.com/ib-77/rop/pkg/rop/solo"
+	"github.com/ib-77/rop/pkg/rop/tracing"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_Solo_ChildrenOfCtxSpan(t *testing.T) {
+	t.Parallel()
+
+	exp := tracing.NewInMemory()
+	tracer := tracing.New(exp, nil)
+
+	ctx, root := tracer.Start(context.Background(), "request")
+	ctx = rop.WithInterceptors(ctx, tracing.Interceptor(tracer))
+
+	r := solo.MapWithCtx(rop.WithStage(ctx, "double"),
+		solo.ValidateWithCtx(ctx, 2, func(_ context.Context, in int) bool { return in > 0 }, "negative"),
+		func(_ context.Context, in int) int { return in * 2 })
+	root.End()
+
+	assert.Equal(t, 4, r.Result())
+	sc, ok := rop.GetMeta(r, tracing.SpanKey)
+	assert.True(t, ok)
+
+	spans := exp.Spans()
+	assert.Len(t, spans, 3)
+	assert.Equal(t, "validate", spans[0].Name)
+	assert.Equal(t, "map(double)", spans[1].Name)
+	assert.Equal(t, "request", spans[2].Name)
+
+	for _, span := range spans[:2] {
+		assert.Equal(t, root.SpanContext().TraceID.String(), span.TraceID)
+		assert.Equal(t, root.SpanContext().SpanID.String(), span.ParentID)
+		assert.Equal(t, "ok", span.Status)
+	}
+	assert.Equal(t, []string{spans[0].TraceID + "-" + spans[0].SpanID}, spans[1].Links)
+	assert.Equal(t, sc.SpanID.String(), spans[1].SpanID)
+	assert.Equal(t, "double", spans[1].Attributes["rop.stage"])
+	assert.Equal(t, "success", spans[1].Attributes["rop.state"])
+}
+
+func Test_Mass_OneTracePerItem(t *testing.T) {
+	t.Parallel()
+
+	exp := tracing.NewInMemory()
+	ctx := rop.WithInterceptors(context.Background(), tracing.Interceptor(tracing.New(exp, nil)))
+
+	inputs := make(chan int, 3)
+	for _, v := range []int{1, 2, 3} {
+		inputs <- v
+	}
+	close(inputs)
+
+	for range mass.Map(ctx,
+		mass.Validate(ctx, inputs, func(_ context.Context, in int) bool { return in > 0 },
+			cancelF[int], "negative", mass.Named("positive")),
+		func(_ context.Context, in int) int { return in * 2 }, cancelF[int], mass.Named("double")) {
+	}
+
+	traces := make(map[string]map[string]tracing.SpanData)
+	for _, span := range exp.Spans() {
+		if traces[span.TraceID] == nil {
+			traces[span.TraceID] = make(map[string]tracing.SpanData)
+		}
+		traces[span.TraceID][span.Name] = span
+	}
+
+	assert.Len(t, traces, 3)
+	for _, trace := range traces {
+		assert.Len(t, trace, 2)
+		assert.Empty(t, trace["validate(positive)"].ParentID)
+		assert.Equal(t, trace["validate(positive)"].SpanID, trace["map(double)"].ParentID)
+	}
+}
+
+func Test_Fail_RecordsError(t *testing.T) {
+	t.Parallel()
+
+	exp := tracing.NewInMemory()
+	ctx := rop.WithInterceptors(rop.WithStage(context.Background(), "charge"),
+		tracing.Interceptor(tracing.New(exp, nil)))
+
+	r := solo.TryWithCtx(ctx, rop.Success(1), func(context.Context, int) (int, error) {
+		return 0, errors.New("declined")
+	})
+
+	assert.False(t, r.IsSuccess())
+
+	spans := exp.Spans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "try(charge)", spans[0].Name)
+	assert.Equal(t, "error", spans[0].Status)
+	assert.Equal(t, "declined", spans[0].StatusDescription)
+	assert.Equal(t, []string{"declined"}, spans[0].Errors)
+	assert.Equal(t, "fail", spans[0].Attributes["rop.state"])
+
+	exp.Reset()
+	assert.Empty(t, exp.Spans())
+}
+
+func Test_JSONFileExporter(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	exp, err := tracing.NewJSONFileExporter(path)
+	assert.NoError(t, err)
+
+	mem := tracing.NewInMemory()
+	ctx := rop.WithInterceptors(context.Background(),
+		tracing.Interceptor(tracing.New(tracing.Multi(exp, mem), nil)))
+
+	solo.MapWithCtx(ctx, solo.ValidateWithCtx(ctx, 1,
+		func(_ context.Context, in int) bool { return in > 0 }, "negative"),
+		func(_ context.Context, in int) string { return "ok" })
+	assert.NoError(t, exp.Close())
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	var spans []tracing.SpanData
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var span tracing.SpanData
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &span))
+		spans = append(spans, span)
+	}
+
+	assert.Len(t, spans, 2)
+	assert.Equal(t, mem.Spans()[0].SpanID, spans[0].SpanID)
+	assert.Equal(t, spans[0].TraceID, spans[1].TraceID)
+	assert.Equal(t, spans[0].SpanID, spans[1].ParentID)
+	assert.Equal(t, "map", spans[1].Attributes["rop.kind"])
+}
+
+func Test_JSONExporter_Writer(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	tracer := tracing.New(tracing.NewJSONExporter(&b), nil)
+
+	_, span := tracer.Start(context.Background(), "op")
+	span.SetAttributes(tracing.Attr("items", 3))
+	span.End()
+	span.End()
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	assert.Len(t, lines, 1)
+	assert.Contains(t, lines[0], `"name":"op"`)
+	assert.Contains(t, lines[0], `"attributes":{"items":3}`)
+	assert.Contains(t, lines[0], `"status":"unset"`)
+}
+
+func Test_ExportError(t *testing.T) {
+	t.Parallel()
+
+	var got error
+	tracer := tracing.New(failingExporter{}, func(err error) { got = err })
+
+	_, span := tracer.Start(context.Background(), "op")
+	span.End()
+
+	assert.EqualError(t, got, "disk full")
+}
+
+type failingExporter struct{}
+
+func (failingExporter) Export(tracing.SpanData) error {
+	return errors.New("disk full")
+}
+
+func cancelF[T any](_ context.Context, in T) error {
+	return errors.New("cancelled")
+}